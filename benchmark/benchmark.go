@@ -0,0 +1,209 @@
+/*
+Package benchmark ejecuta comparativas por lotes de los algoritmos de búsqueda del paquete puzzle.
+
+DESCRIPCIÓN:
+Corre cada combinación de algoritmo y heurística sobre una suite de tableros, leída de un
+archivo o generada a profundidades dadas, y produce resultados por instancia y agregados
+(media, mediana y máximo de nodos, tiempo, longitud de la solución y brecha de optimalidad)
+listos para exportarse como CSV o JSON en los informes de comparación.
+
+API PÚBLICA:
+  - Instancia, LeerSuite, GenerarSuite, GenerarSuiteUniforme: tableros de la suite y su óptimo conocido
+  - Combinacion: algoritmo y heurística a medir, con la función que resuelve cada tablero
+  - Ejecutar, Medicion: ejecución de la suite y resultado de cada par instancia-combinación
+  - Resumir, Resumen, Agregado: estadísticas agregadas por combinación
+  - EscribirCSV, EscribirResumenCSV, EscribirJSON: exportación de los informes
+
+La brecha de optimalidad es la diferencia entre la longitud de la solución encontrada y el
+óptimo conocido: el indicado en la suite o, si falta, la solución más corta obtenida por
+cualquier combinación sobre la misma instancia.
+*/
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"puzzle-solver/puzzle"
+)
+
+// Combinacion es un algoritmo con su heurística, tal como se reporta en los informes.
+type Combinacion struct {
+	Algoritmo  string                                                                      // Nombre del algoritmo
+	Heuristica string                                                                      // Nombre de la heurística (vacío si no usa)
+	Resolver   func(ctx context.Context, inicial puzzle.Tablero) (puzzle.Resultado, error) // Ejecuta la búsqueda hacia el objetivo de la suite
+}
+
+// Medicion es el resultado de una combinación sobre una instancia de la suite.
+type Medicion struct {
+	Instancia    int                 `json:"instancia"`       // Índice de la instancia en la suite (desde 1)
+	Tablero      string              `json:"tablero"`         // Tablero inicial
+	Profundidad  int                 `json:"profundidad"`     // Profundidad de generación (0 si se leyó de un archivo)
+	Algoritmo    string              `json:"algoritmo"`       // Nombre del algoritmo
+	Heuristica   string              `json:"heuristica"`      // Nombre de la heurística
+	Completado   bool                `json:"completado"`      // true si la búsqueda encontró solución dentro del límite
+	Error        string              `json:"error,omitempty"` // Motivo por el que no se completó
+	Pasos        int                 `json:"pasos"`           // Longitud de la solución (-1 si no se completó)
+	Optimo       int                 `json:"optimo"`          // Longitud óptima conocida (-1 si se desconoce)
+	Brecha       int                 `json:"brecha"`          // Pasos menos el óptimo (0 si alguno se desconoce)
+	Estadisticas puzzle.Estadisticas `json:"estadisticas"`    // Métricas de la búsqueda
+}
+
+// errSinSolucion se registra en las mediciones de tableros que no alcanzan el objetivo.
+var errSinSolucion = errors.New("sin solución")
+
+func Ejecutar(ctx context.Context, instancias []Instancia, combinaciones []Combinacion, limite time.Duration, progreso func(hechas, total int, m Medicion)) ([]Medicion, error) {
+	// Ejecutar mide cada combinación sobre cada instancia, en orden, y completa el óptimo y la
+	// brecha de cada medición al terminar la instancia.
+	//
+	// PARÁMETROS:
+	// - ctx: contexto de toda la ejecución; al cancelarse se retornan las mediciones hechas
+	// - instancias: suite de tableros
+	// - combinaciones: algoritmos y heurísticas a comparar
+	// - limite: tiempo máximo de cada búsqueda (0 sin límite); al agotarse la medición queda incompleta
+	// - progreso: función opcional llamada tras cada medición (puede ser nil)
+	//
+	// RETORNA: las mediciones en orden de instancia y combinación, y ctx.Err() si se canceló
+	mediciones := make([]Medicion, 0, len(instancias)*len(combinaciones))
+	total := len(instancias) * len(combinaciones)
+	for i, instancia := range instancias {
+		inicio := len(mediciones)
+		for _, combinacion := range combinaciones {
+			busqueda, cancelar := ctx, context.CancelFunc(func() {})
+			if limite > 0 {
+				busqueda, cancelar = context.WithTimeout(ctx, limite)
+			}
+			resultado, err := combinacion.Resolver(busqueda, instancia.Tablero)
+			cancelar()
+			if ctx.Err() != nil {
+				return mediciones, ctx.Err()
+			}
+			if err == nil && len(resultado.Camino) == 0 {
+				err = errSinSolucion
+			}
+
+			m := Medicion{
+				Instancia:    i + 1,
+				Tablero:      instancia.Tablero.String(),
+				Profundidad:  instancia.Profundidad,
+				Algoritmo:    combinacion.Algoritmo,
+				Heuristica:   combinacion.Heuristica,
+				Completado:   err == nil,
+				Pasos:        len(resultado.Camino) - 1,
+				Optimo:       instancia.Optimo,
+				Estadisticas: resultado.Estadisticas,
+			}
+			if err != nil {
+				m.Error = err.Error()
+				m.Pasos = -1
+			}
+			mediciones = append(mediciones, m)
+			if progreso != nil {
+				progreso(len(mediciones), total, m)
+			}
+		}
+		completarOptimo(mediciones[inicio:])
+	}
+	return mediciones, nil
+}
+
+func completarOptimo(mediciones []Medicion) {
+	// completarOptimo toma como óptimo la solución más corta encontrada cuando la suite no lo
+	// indica, y calcula la brecha de cada medición completada de la instancia.
+	optimo := -1
+	if len(mediciones) > 0 {
+		optimo = mediciones[0].Optimo
+	}
+	if optimo < 0 {
+		for _, m := range mediciones {
+			if m.Completado && (optimo < 0 || m.Pasos < optimo) {
+				optimo = m.Pasos
+			}
+		}
+	}
+	for i := range mediciones {
+		mediciones[i].Optimo = optimo
+		if mediciones[i].Completado && optimo >= 0 {
+			mediciones[i].Brecha = mediciones[i].Pasos - optimo
+		}
+	}
+}
+
+// Agregado resume una métrica sobre las mediciones completadas de una combinación.
+type Agregado struct {
+	Media   float64 `json:"media"`
+	Mediana float64 `json:"mediana"`
+	Maximo  float64 `json:"maximo"`
+}
+
+// Resumen reúne las estadísticas agregadas de una combinación sobre toda la suite.
+type Resumen struct {
+	Algoritmo  string   `json:"algoritmo"`
+	Heuristica string   `json:"heuristica"`
+	Instancias int      `json:"instancias"` // Instancias ejecutadas
+	Resueltas  int      `json:"resueltas"`  // Instancias completadas dentro del límite
+	Optimas    int      `json:"optimas"`    // Soluciones con brecha 0
+	Nodos      Agregado `json:"nodos_expandidos"`
+	TiempoMs   Agregado `json:"tiempo_ms"`
+	Pasos      Agregado `json:"pasos"`
+	Brecha     Agregado `json:"brecha"`
+}
+
+func Resumir(mediciones []Medicion) []Resumen {
+	// Resumir agrupa las mediciones por combinación, en el orden en que aparecen, y agrega
+	// nodos expandidos, tiempo, longitud de la solución y brecha de las mediciones completadas.
+	indices := map[[2]string]int{}
+	resumenes := []Resumen{}
+	muestras := [][4][]float64{} // Nodos, tiempo, pasos y brecha de cada combinación
+	for _, m := range mediciones {
+		clave := [2]string{m.Algoritmo, m.Heuristica}
+		i, existe := indices[clave]
+		if !existe {
+			i = len(resumenes)
+			indices[clave] = i
+			resumenes = append(resumenes, Resumen{Algoritmo: m.Algoritmo, Heuristica: m.Heuristica})
+			muestras = append(muestras, [4][]float64{})
+		}
+		resumenes[i].Instancias++
+		if !m.Completado {
+			continue
+		}
+		resumenes[i].Resueltas++
+		if m.Brecha == 0 {
+			resumenes[i].Optimas++
+		}
+		muestras[i][0] = append(muestras[i][0], float64(m.Estadisticas.NodosExpandidos))
+		muestras[i][1] = append(muestras[i][1], float64(m.Estadisticas.Tiempo.Microseconds())/1000)
+		muestras[i][2] = append(muestras[i][2], float64(m.Pasos))
+		muestras[i][3] = append(muestras[i][3], float64(m.Brecha))
+	}
+	for i := range resumenes {
+		resumenes[i].Nodos = agregar(muestras[i][0])
+		resumenes[i].TiempoMs = agregar(muestras[i][1])
+		resumenes[i].Pasos = agregar(muestras[i][2])
+		resumenes[i].Brecha = agregar(muestras[i][3])
+	}
+	return resumenes
+}
+
+func agregar(valores []float64) Agregado {
+	// agregar calcula media, mediana y máximo; retorna ceros si no hay valores.
+	if len(valores) == 0 {
+		return Agregado{}
+	}
+	ordenados := append([]float64(nil), valores...)
+	sort.Float64s(ordenados)
+
+	suma := 0.0
+	for _, v := range ordenados {
+		suma += v
+	}
+	mitad := len(ordenados) / 2
+	mediana := ordenados[mitad]
+	if len(ordenados)%2 == 0 {
+		mediana = (ordenados[mitad-1] + ordenados[mitad]) / 2
+	}
+	return Agregado{Media: suma / float64(len(ordenados)), Mediana: mediana, Maximo: ordenados[len(ordenados)-1]}
+}
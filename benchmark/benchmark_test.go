@@ -0,0 +1,163 @@
+package benchmark
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"puzzle-solver/puzzle"
+)
+
+func TestAgregar(t *testing.T) {
+	// La mediana es el valor central con una cantidad impar y la media de los dos centrales con
+	// una par, sin importar el orden de entrada.
+	casos := []struct {
+		nombre  string
+		valores []float64
+		want    Agregado
+	}{
+		{"vacío", nil, Agregado{}},
+		{"uno", []float64{7}, Agregado{Media: 7, Mediana: 7, Maximo: 7}},
+		{"impar", []float64{9, 1, 5}, Agregado{Media: 5, Mediana: 5, Maximo: 9}},
+		{"par", []float64{10, 2, 4, 8}, Agregado{Media: 6, Mediana: 6, Maximo: 10}},
+		{"par con centrales distintos", []float64{1, 2, 3, 100}, Agregado{Media: 26.5, Mediana: 2.5, Maximo: 100}},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			entrada := append([]float64(nil), c.valores...)
+			if got := agregar(entrada); got != c.want {
+				t.Fatalf("agregar(%v) = %+v, se esperaba %+v", c.valores, got, c.want)
+			}
+			for i := range entrada {
+				if entrada[i] != c.valores[i] {
+					t.Fatalf("agregar modificó los valores recibidos: %v", entrada)
+				}
+			}
+		})
+	}
+}
+
+func TestCompletarOptimo(t *testing.T) {
+	// La brecha se mide contra el óptimo de la suite si se conoce y, si no, contra la solución más
+	// corta de la instancia; las mediciones incompletas conservan brecha 0.
+	casos := []struct {
+		nombre      string
+		optimo      int
+		pasos       []int // -1 marca una medición no completada
+		wantOptimo  int
+		wantBrechas []int
+	}{
+		{"óptimo conocido", 10, []int{10, 14, -1}, 10, []int{0, 4, 0}},
+		{"óptimo conocido no alcanzado", 10, []int{12, 16}, 10, []int{2, 6}},
+		{"óptimo desconocido", -1, []int{18, -1, 16, 20}, 16, []int{2, 0, 0, 4}},
+		{"ninguna completada", -1, []int{-1, -1}, -1, []int{0, 0}},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			mediciones := make([]Medicion, len(c.pasos))
+			for i, pasos := range c.pasos {
+				mediciones[i] = Medicion{Pasos: pasos, Optimo: c.optimo, Completado: pasos >= 0}
+			}
+			completarOptimo(mediciones)
+			for i, m := range mediciones {
+				if m.Optimo != c.wantOptimo || m.Brecha != c.wantBrechas[i] {
+					t.Fatalf("medición %d: óptimo %d y brecha %d, se esperaba %d y %d", i, m.Optimo, m.Brecha, c.wantOptimo, c.wantBrechas[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResumir(t *testing.T) {
+	// Las combinaciones se resumen en el orden en que aparecen y solo las mediciones completadas
+	// entran en los agregados.
+	medicion := func(algoritmo, heuristica string, pasos, brecha, nodos int, tiempo time.Duration) Medicion {
+		return Medicion{
+			Algoritmo: algoritmo, Heuristica: heuristica, Completado: pasos >= 0, Pasos: pasos, Brecha: brecha,
+			Estadisticas: puzzle.Estadisticas{NodosExpandidos: nodos, Tiempo: tiempo},
+		}
+	}
+	mediciones := []Medicion{
+		medicion("idaestrella", "manhattan", 20, 0, 300, 3*time.Millisecond),
+		medicion("anchura", "", 20, 0, 9000, 40*time.Millisecond),
+		medicion("idaestrella", "manhattan", 24, 2, 500, 5*time.Millisecond),
+		medicion("anchura", "", -1, 0, 100000, time.Second),
+		medicion("idaestrella", "manhattan", 10, 0, 100, time.Millisecond),
+	}
+	got := Resumir(mediciones)
+	want := []Resumen{
+		{
+			Algoritmo: "idaestrella", Heuristica: "manhattan", Instancias: 3, Resueltas: 3, Optimas: 2,
+			Nodos:    Agregado{Media: 300, Mediana: 300, Maximo: 500},
+			TiempoMs: Agregado{Media: 3, Mediana: 3, Maximo: 5},
+			Pasos:    Agregado{Media: 18, Mediana: 20, Maximo: 24},
+			Brecha:   Agregado{Media: 2.0 / 3, Mediana: 0, Maximo: 2},
+		},
+		{
+			Algoritmo: "anchura", Instancias: 2, Resueltas: 1, Optimas: 1,
+			Nodos:    Agregado{Media: 9000, Mediana: 9000, Maximo: 9000},
+			TiempoMs: Agregado{Media: 40, Mediana: 40, Maximo: 40},
+			Pasos:    Agregado{Media: 20, Mediana: 20, Maximo: 20},
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Resumir retornó %d combinaciones, se esperaban %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("resumen %d = %+v\nse esperaba %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEjecutarBrecha(t *testing.T) {
+	// Ejecutar completa el óptimo y la brecha por instancia y marca como incompletas las búsquedas
+	// sin solución o que agotan el límite de tiempo.
+	inicial, err := puzzle.ParsearTablero(3, 3, "1 2 3 4 5 6 0 7 8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	camino := func(pasos int) puzzle.Resultado {
+		return puzzle.Resultado{Camino: make([]puzzle.Estado, pasos+1)}
+	}
+	combinaciones := []Combinacion{
+		{Algoritmo: "optimo", Resolver: func(ctx context.Context, inicial puzzle.Tablero) (puzzle.Resultado, error) {
+			return camino(2), nil
+		}},
+		{Algoritmo: "largo", Resolver: func(ctx context.Context, inicial puzzle.Tablero) (puzzle.Resultado, error) {
+			return camino(6), nil
+		}},
+		{Algoritmo: "sin camino", Resolver: func(ctx context.Context, inicial puzzle.Tablero) (puzzle.Resultado, error) {
+			return puzzle.Resultado{}, nil
+		}},
+		{Algoritmo: "lento", Resolver: func(ctx context.Context, inicial puzzle.Tablero) (puzzle.Resultado, error) {
+			<-ctx.Done()
+			return puzzle.Resultado{}, ctx.Err()
+		}},
+	}
+	instancias := []Instancia{{Tablero: inicial, Optimo: -1}, {Tablero: inicial, Optimo: 1}}
+	mediciones, err := Ejecutar(context.Background(), instancias, combinaciones, time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		completado            bool
+		pasos, optimo, brecha int
+	}{
+		{true, 2, 2, 0}, {true, 6, 2, 4}, {false, -1, 2, 0}, {false, -1, 2, 0},
+		{true, 2, 1, 1}, {true, 6, 1, 5}, {false, -1, 1, 0}, {false, -1, 1, 0},
+	}
+	if len(mediciones) != len(want) {
+		t.Fatalf("%d mediciones, se esperaban %d", len(mediciones), len(want))
+	}
+	for i, m := range mediciones {
+		w := want[i]
+		if m.Completado != w.completado || m.Pasos != w.pasos || m.Optimo != w.optimo || m.Brecha != w.brecha {
+			t.Errorf("medición %d (%s) = %+v, se esperaba %+v", i, m.Algoritmo, m, w)
+		}
+		if m.Completado == (m.Error != "") {
+			t.Errorf("medición %d (%s): completado %v con error %q", i, m.Algoritmo, m.Completado, m.Error)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package benchmark
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Informe es el contenido completo de una comparativa exportada como JSON.
+type Informe struct {
+	Mediciones []Medicion `json:"mediciones"`
+	Resumen    []Resumen  `json:"resumen"`
+}
+
+func EscribirCSV(w io.Writer, mediciones []Medicion) error {
+	// EscribirCSV exporta una fila por medición, con las estadísticas completas de la búsqueda.
+	// El tiempo se expresa en milisegundos y la memoria estimada en bytes.
+	escritor := csv.NewWriter(w)
+	escritor.Write([]string{
+		"instancia", "tablero", "profundidad", "algoritmo", "heuristica", "completado", "error",
+		"pasos", "optimo", "brecha", "nodos_expandidos", "nodos_generados", "max_frontera",
+		"max_cerrada", "duplicados_podados", "factor_ramificacion", "memoria_estimada", "tiempo_ms",
+	})
+	for _, m := range mediciones {
+		e := m.Estadisticas
+		escritor.Write([]string{
+			strconv.Itoa(m.Instancia), m.Tablero, strconv.Itoa(m.Profundidad), m.Algoritmo, m.Heuristica,
+			strconv.FormatBool(m.Completado), m.Error, strconv.Itoa(m.Pasos), strconv.Itoa(m.Optimo),
+			strconv.Itoa(m.Brecha), strconv.Itoa(e.NodosExpandidos), strconv.Itoa(e.NodosGenerados),
+			strconv.Itoa(e.MaxFrontera), strconv.Itoa(e.MaxCerrada), strconv.Itoa(e.DuplicadosPodados),
+			formatear(e.FactorRamificacion), strconv.FormatInt(e.MemoriaEstimada, 10),
+			formatear(float64(e.Tiempo.Microseconds()) / 1000),
+		})
+	}
+	escritor.Flush()
+	return escritor.Error()
+}
+
+func EscribirResumenCSV(w io.Writer, resumenes []Resumen) error {
+	// EscribirResumenCSV exporta una fila por combinación con la media, mediana y máximo
+	// de cada métrica agregada.
+	escritor := csv.NewWriter(w)
+	encabezado := []string{"algoritmo", "heuristica", "instancias", "resueltas", "optimas"}
+	for _, metrica := range []string{"nodos_expandidos", "tiempo_ms", "pasos", "brecha"} {
+		encabezado = append(encabezado, metrica+"_media", metrica+"_mediana", metrica+"_maximo")
+	}
+	escritor.Write(encabezado)
+	for _, r := range resumenes {
+		fila := []string{r.Algoritmo, r.Heuristica, strconv.Itoa(r.Instancias), strconv.Itoa(r.Resueltas), strconv.Itoa(r.Optimas)}
+		for _, a := range []Agregado{r.Nodos, r.TiempoMs, r.Pasos, r.Brecha} {
+			fila = append(fila, formatear(a.Media), formatear(a.Mediana), formatear(a.Maximo))
+		}
+		escritor.Write(fila)
+	}
+	escritor.Flush()
+	return escritor.Error()
+}
+
+func EscribirJSON(w io.Writer, informe Informe) error {
+	// EscribirJSON exporta las mediciones y el resumen como un único documento JSON con sangría.
+	codificador := json.NewEncoder(w)
+	codificador.SetIndent("", "  ")
+	return codificador.Encode(informe)
+}
+
+func formatear(valor float64) string {
+	// formatear escribe un número real redondeado a milésimas, sin ceros sobrantes.
+	return strconv.FormatFloat(math.Round(valor*1000)/1000, 'f', -1, 64)
+}
@@ -0,0 +1,102 @@
+package benchmark
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"puzzle-solver/puzzle"
+)
+
+func medicionesInforme() []Medicion {
+	// medicionesInforme retorna una medición completada y otra interrumpida, con un tablero que
+	// contiene espacios y un error que contiene una coma para ejercitar el entrecomillado del CSV.
+	return []Medicion{
+		{
+			Instancia: 1, Tablero: "1 2 3 4 5 6 0 7 8", Profundidad: 2, Algoritmo: "aestrella", Heuristica: "manhattan",
+			Completado: true, Pasos: 2, Optimo: 2,
+			Estadisticas: puzzle.Estadisticas{
+				NodosExpandidos: 3, NodosGenerados: 7, MaxFrontera: 5, MaxCerrada: 3, DuplicadosPodados: 1,
+				FactorRamificacion: 1.61803, MemoriaEstimada: 1024, Tiempo: 1500 * time.Microsecond,
+			},
+		},
+		{
+			Instancia: 1, Tablero: "1 2 3 4 5 6 0 7 8", Profundidad: 2, Algoritmo: "anchura",
+			Error: "límite agotado, sin solución", Pasos: -1, Optimo: 2,
+		},
+	}
+}
+
+func TestEscribirCSV(t *testing.T) {
+	// El CSV tiene un encabezado y una fila por medición con la misma cantidad de columnas; los
+	// reales se redondean a milésimas y el tiempo se expresa en milisegundos.
+	var salida bytes.Buffer
+	if err := EscribirCSV(&salida, medicionesInforme()); err != nil {
+		t.Fatal(err)
+	}
+	filas, err := csv.NewReader(&salida).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encabezado := []string{
+		"instancia", "tablero", "profundidad", "algoritmo", "heuristica", "completado", "error",
+		"pasos", "optimo", "brecha", "nodos_expandidos", "nodos_generados", "max_frontera",
+		"max_cerrada", "duplicados_podados", "factor_ramificacion", "memoria_estimada", "tiempo_ms",
+	}
+	want := [][]string{
+		encabezado,
+		{"1", "1 2 3 4 5 6 0 7 8", "2", "aestrella", "manhattan", "true", "", "2", "2", "0", "3", "7", "5", "3", "1", "1.618", "1024", "1.5"},
+		{"1", "1 2 3 4 5 6 0 7 8", "2", "anchura", "", "false", "límite agotado, sin solución", "-1", "2", "0", "0", "0", "0", "0", "0", "0", "0", "0"},
+	}
+	if !reflect.DeepEqual(filas, want) {
+		t.Fatalf("CSV =\n%q\nse esperaba\n%q", filas, want)
+	}
+}
+
+func TestEscribirResumenCSV(t *testing.T) {
+	// El resumen tiene cinco columnas fijas y media, mediana y máximo de cada una de las cuatro métricas.
+	var salida bytes.Buffer
+	if err := EscribirResumenCSV(&salida, Resumir(medicionesInforme())); err != nil {
+		t.Fatal(err)
+	}
+	filas, err := csv.NewReader(&salida).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filas) != 3 {
+		t.Fatalf("%d filas, se esperaban el encabezado y dos combinaciones", len(filas))
+	}
+	if len(filas[0]) != 5+4*3 || filas[0][5] != "nodos_expandidos_media" || filas[0][16] != "brecha_maximo" {
+		t.Fatalf("encabezado inesperado: %q", filas[0])
+	}
+	if got, want := filas[1][:8], []string{"aestrella", "manhattan", "1", "1", "1", "3", "3", "3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("fila de aestrella = %q, se esperaba que comenzara con %q", filas[1], want)
+	}
+	if got, want := filas[2][:5], []string{"anchura", "", "1", "0", "0"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("fila de anchura = %q, se esperaba que comenzara con %q", filas[2], want)
+	}
+}
+
+func TestEscribirJSON(t *testing.T) {
+	// El informe JSON se lee de vuelta con las mismas mediciones y resúmenes, y omite el error vacío.
+	mediciones := medicionesInforme()
+	informe := Informe{Mediciones: mediciones, Resumen: Resumir(mediciones)}
+	var salida bytes.Buffer
+	if err := EscribirJSON(&salida, informe); err != nil {
+		t.Fatal(err)
+	}
+	var leido Informe
+	if err := json.Unmarshal(salida.Bytes(), &leido); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(leido, informe) {
+		t.Fatalf("informe leído = %+v\nse esperaba %+v", leido, informe)
+	}
+	if primera, _, _ := strings.Cut(salida.String(), `"error"`); !strings.Contains(primera, `"estadisticas"`) {
+		t.Fatal("la medición completada no debería incluir el campo error")
+	}
+}
@@ -0,0 +1,99 @@
+package benchmark
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"puzzle-solver/puzzle"
+)
+
+// Instancia es un tablero de la suite de comparación.
+type Instancia struct {
+	Tablero     puzzle.Tablero // Configuración inicial
+	Optimo      int            // Longitud óptima conocida (-1 si se desconoce)
+	Profundidad int            // Profundidad exacta con que se generó (0 si se leyó de un archivo)
+}
+
+func LeerSuite(r io.Reader, parsear func(texto string) (puzzle.Tablero, error)) ([]Instancia, error) {
+	// LeerSuite lee una suite con un tablero por línea. Las líneas vacías y las que comienzan
+	// con # se ignoran, y el óptimo conocido puede indicarse tras un signo igual:
+	//
+	//	# tableros 3x3
+	//	8 6 7 2 5 4 3 0 1 = 31
+	//	1 2 3 4 0 6 7 5 8
+	//
+	// PARÁMETROS:
+	// - r: origen de la suite
+	// - parsear: convierte el texto de un tablero, lo que permite al llamador fijar o deducir las dimensiones
+	//
+	// RETORNA: las instancias, o un error que indica la línea inválida
+	instancias := []Instancia{}
+	lector := bufio.NewScanner(r)
+	for linea := 1; lector.Scan(); linea++ {
+		texto := strings.TrimSpace(lector.Text())
+		if texto == "" || strings.HasPrefix(texto, "#") {
+			continue
+		}
+
+		instancia := Instancia{Optimo: -1}
+		if tablero, optimo, existe := strings.Cut(texto, "="); existe {
+			valor, err := strconv.Atoi(strings.TrimSpace(optimo))
+			if err != nil || valor < 0 {
+				return nil, fmt.Errorf("línea %d: óptimo inválido %q", linea, strings.TrimSpace(optimo))
+			}
+			texto, instancia.Optimo = tablero, valor
+		}
+		tablero, err := parsear(texto)
+		if err != nil {
+			return nil, fmt.Errorf("línea %d: %w", linea, err)
+		}
+		instancia.Tablero = tablero
+		instancias = append(instancias, instancia)
+	}
+	if err := lector.Err(); err != nil {
+		return nil, err
+	}
+	if len(instancias) == 0 {
+		return nil, fmt.Errorf("la suite no contiene tableros")
+	}
+	return instancias, nil
+}
+
+func GenerarSuite(ctx context.Context, objetivo puzzle.Tablero, profundidades []int, porProfundidad int, tabla *puzzle.TablaDistancias, rng *rand.Rand) ([]Instancia, error) {
+	// GenerarSuite crea porProfundidad tableros para cada profundidad cuya solución óptima tiene
+	// exactamente esa longitud (ver puzzle.MezclarDistancia), por lo que el óptimo es conocido
+	// y la brecha de cada medición es exacta. La misma semilla de rng produce la misma suite.
+	// tabla es la tabla de distancias del objetivo o nil; en tableros pequeños conviene pasarla
+	// para no construirla en cada tablero.
+	instancias := make([]Instancia, 0, len(profundidades)*porProfundidad)
+	for _, profundidad := range profundidades {
+		for i := 0; i < porProfundidad; i++ {
+			tablero, err := puzzle.MezclarDistancia(ctx, objetivo, profundidad, tabla, rng)
+			if err != nil {
+				return nil, err
+			}
+			instancias = append(instancias, Instancia{
+				Tablero:     tablero,
+				Optimo:      profundidad,
+				Profundidad: profundidad,
+			})
+		}
+	}
+	return instancias, nil
+}
+
+func GenerarSuiteUniforme(objetivo puzzle.Tablero, cantidad int, rng *rand.Rand) []Instancia {
+	// GenerarSuiteUniforme crea cantidad tableros elegidos uniformemente entre todos los resolubles
+	// (ver puzzle.MezclarUniforme), una muestra representativa del espacio de estados para
+	// comparaciones estadísticas. El óptimo queda como desconocido.
+	instancias := make([]Instancia, cantidad)
+	for i := range instancias {
+		instancias[i] = Instancia{Tablero: puzzle.MezclarUniforme(objetivo, rng), Optimo: -1}
+	}
+	return instancias
+}
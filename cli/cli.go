@@ -0,0 +1,405 @@
+/*
+Package cli implementa el modo de línea de comandos del resolvedor, sin interfaz gráfica.
+
+DESCRIPCIÓN:
+Permite ejecutar experimentos en servidores sin pantalla. Solo depende del paquete puzzle,
+por lo que puede compilarse sin Fyne (ver cmd/puzzle-cli) o invocarse desde la aplicación
+gráfica cuando recibe un subcomando. Las órdenes que generan tableros al azar (shuffle y bench
+con -profundidades o -uniformes) aceptan -semilla para repetir exactamente la misma generación; si no se
+indica, eligen una y la informan en la salida de errores.
+
+SUBCOMANDOS:
+  - solve: resuelve un tablero e imprime la secuencia de movimientos y las estadísticas
+  - shuffle: genera tableros mezclados a partir del objetivo
+  - bench: ejecuta todas las combinaciones de algoritmo y heurística sobre un tablero o una suite
+  - check: verifica la resolubilidad de un tablero y explica el veredicto (sale con 3 si no es resoluble)
+  - table: enumera todos los estados del 8-puzzle (o menores) con su distancia óptima exacta
+
+EJEMPLO DE USO:
+
+	puzzle-cli solve -tablero "8 6 7 2 5 4 3 0 1" -algoritmo idaestrella -heuristica conflicto
+	puzzle-cli shuffle -filas 4 -columnas 4 -cantidad 10 > tableros.txt
+	puzzle-cli shuffle -profundidad 20 -cantidad 5 -semilla 42
+	puzzle-cli shuffle -modo uniforme -cantidad 100 > uniformes.txt
+	puzzle-cli check -archivo tablero.txt -formato json
+	puzzle-cli bench -profundidades 10,20,30 -heuristicas manhattan,conflicto -csv resultados.csv
+	puzzle-cli table -objetivo espiral -validar manhattan,conflicto,pdb
+*/
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"puzzle-solver/puzzle"
+)
+
+// subcomando describe una orden de la línea de comandos.
+type subcomando struct {
+	descripcion string                                               // Resumen mostrado en la ayuda general
+	ejecutar    func(args []string, salida, errores io.Writer) error // Implementación de la orden
+}
+
+// subcomandos asocia cada nombre con su implementación.
+var subcomandos = map[string]subcomando{
+	"solve":   {"Resuelve un tablero e imprime los movimientos y las estadísticas", ejecutarSolve},
+	"shuffle": {"Genera tableros mezclados a partir del objetivo", ejecutarShuffle},
+	"bench":   {"Compara todas las combinaciones de algoritmo y heurística sobre un tablero o una suite", ejecutarBench},
+	"check":   {"Verifica la resolubilidad de un tablero y explica el veredicto", ejecutarCheck},
+	"table":   {"Enumera todos los estados de un tablero pequeño con su distancia óptima exacta", ejecutarTable},
+}
+
+// errUso indica un error en los argumentos; el mensaje ya fue impreso por el FlagSet.
+var errUso = errors.New("uso incorrecto")
+
+// errAyuda indica que se pidió la ayuda de un subcomando con -h; no es un fallo.
+var errAyuda = errors.New("ayuda solicitada")
+
+// errIrresoluble indica que check determinó que el tablero no tiene solución; el veredicto
+// ya fue impreso, por lo que solo cambia el código de salida.
+var errIrresoluble = errors.New("tablero irresoluble")
+
+func EsSubcomando(nombre string) bool {
+	// EsSubcomando indica si el argumento corresponde a una orden de la línea de comandos
+	// (incluida la ayuda). La aplicación gráfica lo usa para decidir si abrir la ventana.
+	_, existe := subcomandos[nombre]
+	return existe || nombre == "help" || nombre == "-h" || nombre == "--help"
+}
+
+func Ejecutar(args []string, salida, errores io.Writer) int {
+	// Ejecutar interpreta args (sin el nombre del programa), ejecuta el subcomando indicado
+	// y retorna el código de salida del proceso: 0 si tuvo éxito, 1 ante un error, 2 ante
+	// argumentos incorrectos y 3 si check determina que el tablero no tiene solución.
+	if len(args) == 0 || !EsSubcomando(args[0]) || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		ayuda(errores)
+		if len(args) == 0 || !EsSubcomando(args[0]) {
+			return 2
+		}
+		return 0
+	}
+
+	err := subcomandos[args[0]].ejecutar(args[1:], salida, errores)
+	switch {
+	case err == nil, errors.Is(err, errAyuda):
+		return 0
+	case errors.Is(err, errUso):
+		return 2
+	case errors.Is(err, errIrresoluble):
+		return 3
+	default:
+		fmt.Fprintf(errores, "error: %v\n", err)
+		return 1
+	}
+}
+
+func ayuda(w io.Writer) {
+	// ayuda imprime la lista de subcomandos disponibles.
+	fmt.Fprintln(w, "Uso: puzzle-solver <subcomando> [opciones]")
+	fmt.Fprintln(w, "Sin subcomando se abre la interfaz gráfica.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Subcomandos:")
+	for _, nombre := range []string{"solve", "shuffle", "bench", "check", "table"} {
+		fmt.Fprintf(w, "  %-8s %s\n", nombre, subcomandos[nombre].descripcion)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usa 'puzzle-solver <subcomando> -h' para ver las opciones de cada subcomando.")
+}
+
+func nuevoFlagSet(nombre string, errores io.Writer) *flag.FlagSet {
+	// nuevoFlagSet crea el conjunto de opciones de un subcomando, con los errores dirigidos a errores.
+	fs := flag.NewFlagSet(nombre, flag.ContinueOnError)
+	fs.SetOutput(errores)
+	return fs
+}
+
+func analizar(fs *flag.FlagSet, args []string) error {
+	// analizar procesa las opciones; -h retorna errAyuda y cualquier otro problema errUso.
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return errAyuda
+		}
+		return errUso
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "argumentos no reconocidos: %v\n", fs.Args())
+		return errUso
+	}
+	return nil
+}
+
+// opcionesTablero agrupa las opciones comunes para indicar el tablero y el objetivo.
+type opcionesTablero struct {
+	tablero  string // Tablero como texto, por ejemplo "1 2 3 4 0 6 7 5 8"
+	archivo  string // Archivo con el tablero (alternativa a tablero; "-" lee la entrada estándar)
+	filas    int    // Filas del tablero (0 para deducirlas)
+	columnas int    // Columnas del tablero (0 para deducirlas)
+	objetivo string // estandar, vacio-inicial, espiral o un tablero como texto
+}
+
+func (o *opcionesTablero) registrar(fs *flag.FlagSet, conTablero bool) {
+	// registrar declara las opciones en el FlagSet; los subcomandos que no leen un tablero
+	// (como shuffle) solo declaran las dimensiones y el objetivo.
+	if conTablero {
+		fs.StringVar(&o.tablero, "tablero", "", "tablero como texto, por ejemplo \"1 2 3 4 0 6 7 5 8\"")
+		fs.StringVar(&o.archivo, "archivo", "", "archivo con el tablero (\"-\" para la entrada estándar)")
+	}
+	fs.IntVar(&o.filas, "filas", 0, "filas del tablero (por omisión se deducen del número de valores)")
+	fs.IntVar(&o.columnas, "columnas", 0, "columnas del tablero (por omisión se deducen del número de valores)")
+	fs.StringVar(&o.objetivo, "objetivo", "estandar", "objetivo: estandar, vacio-inicial, espiral o un tablero como texto")
+}
+
+func (o *opcionesTablero) leer() (inicial, objetivo puzzle.Tablero, err error) {
+	// leer obtiene el tablero inicial (de -tablero o -archivo) y el objetivo de las mismas dimensiones.
+	texto := o.tablero
+	if o.archivo != "" {
+		if texto != "" {
+			return inicial, objetivo, errors.New("indica -tablero o -archivo, no ambos")
+		}
+		var datos []byte
+		if o.archivo == "-" {
+			datos, err = io.ReadAll(os.Stdin)
+		} else {
+			datos, err = os.ReadFile(o.archivo)
+		}
+		if err != nil {
+			return inicial, objetivo, err
+		}
+		texto = string(datos)
+	}
+	if strings.TrimSpace(texto) == "" {
+		return inicial, objetivo, errors.New("falta el tablero: usa -tablero o -archivo")
+	}
+
+	filas, columnas, err := dimensiones(contarValores(texto), o.filas, o.columnas)
+	if err != nil {
+		return inicial, objetivo, err
+	}
+	if inicial, err = puzzle.ParsearTablero(filas, columnas, texto); err != nil {
+		return inicial, objetivo, fmt.Errorf("tablero inválido: %w", err)
+	}
+	objetivo, err = o.leerObjetivo(filas, columnas)
+	return inicial, objetivo, err
+}
+
+func (o *opcionesTablero) leerObjetivo(filas, columnas int) (puzzle.Tablero, error) {
+	// leerObjetivo construye el objetivo indicado con -objetivo para las dimensiones dadas.
+	switch o.objetivo {
+	case "", "estandar":
+		return puzzle.TableroObjetivo(filas, columnas), nil
+	case "vacio-inicial":
+		return puzzle.ObjetivoVacioInicial(filas, columnas), nil
+	case "espiral":
+		return puzzle.ObjetivoEspiral(filas, columnas), nil
+	}
+	objetivo, err := puzzle.ParsearTablero(filas, columnas, o.objetivo)
+	if err != nil {
+		return objetivo, fmt.Errorf("objetivo inválido: %w", err)
+	}
+	return objetivo, nil
+}
+
+func (o *opcionesTablero) dimensionesGeneracion() (int, int, error) {
+	// dimensionesGeneracion retorna las dimensiones de los tableros generados: 3x3 por omisión
+	// y cuadrado si solo se indican las filas.
+	filas, columnas := o.filas, o.columnas
+	if filas == 0 {
+		filas = 3
+	}
+	if columnas == 0 {
+		columnas = filas
+	}
+	if _, err := puzzle.NuevoTablero(filas, columnas, puzzle.TableroObjetivo(filas, columnas).Valores()); err != nil {
+		return 0, 0, err // Dimensiones fuera de rango
+	}
+	return filas, columnas, nil
+}
+
+func contarValores(texto string) int {
+	// contarValores cuenta los valores del tablero con los mismos separadores que ParsearTablero.
+	return len(strings.FieldsFunc(texto, func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';' || r == '\t' || r == '\n' || r == '\r'
+	}))
+}
+
+func dimensiones(valores, filas, columnas int) (int, int, error) {
+	// dimensiones completa las filas y columnas no indicadas a partir del número de valores:
+	// si falta una se divide, y si faltan ambas se asume un tablero cuadrado.
+	switch {
+	case filas > 0 && columnas > 0:
+		return filas, columnas, nil
+	case filas > 0:
+		return filas, valores / filas, nil
+	case columnas > 0:
+		return valores / columnas, columnas, nil
+	}
+	for lado := puzzle.DimensionMinima; lado <= puzzle.DimensionMaxima; lado++ {
+		if lado*lado == valores {
+			return lado, lado, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("%d valores no forman un tablero cuadrado: indica -filas y -columnas", valores)
+}
+
+// Nombres de los algoritmos aceptados por -algoritmo.
+const (
+	algoritmoAEstrella   = "aestrella"
+	algoritmoIDAEstrella = "idaestrella"
+	algoritmoAnchura     = "anchura"
+)
+
+// Nombres de las heurísticas aceptadas por -heuristica.
+const (
+	heuristicaManhattan       = "manhattan"
+	heuristicaConflictoLineal = "conflicto"
+	heuristicaPatrones        = "pdb"
+	heuristicaExacta          = "exacta" // Distancia de la tabla completa, solo hasta 3x3
+)
+
+// sinonimos permite escribir los algoritmos con sus nombres habituales en inglés.
+var sinonimos = map[string]string{
+	"astar":   algoritmoAEstrella,
+	"idastar": algoritmoIDAEstrella,
+	"bfs":     algoritmoAnchura,
+}
+
+func normalizarAlgoritmo(nombre string) (string, error) {
+	// normalizarAlgoritmo valida el nombre del algoritmo y traduce los sinónimos.
+	if canonico, existe := sinonimos[nombre]; existe {
+		nombre = canonico
+	}
+	switch nombre {
+	case algoritmoAEstrella, algoritmoIDAEstrella, algoritmoAnchura:
+		return nombre, nil
+	}
+	return "", fmt.Errorf("algoritmo %q desconocido (aestrella, idaestrella o anchura)", nombre)
+}
+
+func construirHeuristica(nombre string, objetivo puzzle.Tablero, errores io.Writer) (puzzle.Heuristica, error) {
+	// construirHeuristica crea la heurística indicada para el objetivo. La base de patrones y la
+	// tabla de distancias se cargan de la caché en disco o se construyen la primera vez, informando
+	// por errores.
+	switch nombre {
+	case heuristicaManhattan:
+		return puzzle.NuevaHeuristicaManhattan(objetivo), nil
+	case heuristicaConflictoLineal:
+		return puzzle.NuevaHeuristicaConflictoLineal(objetivo), nil
+	case heuristicaPatrones:
+		grupos := puzzle.GruposPredeterminados(objetivo.Filas(), objetivo.Columnas())
+		aviso := avisoConstruccion(errores, fmt.Sprintf("base de patrones %dx%d %v", objetivo.Filas(), objetivo.Columnas(), grupos))
+		base, err := puzzle.ObtenerBasePatrones(objetivo, grupos, aviso)
+		if err != nil {
+			fmt.Fprintf(errores, "advertencia: %v\n", err)
+		}
+		return base.Evaluar, nil
+	case heuristicaExacta:
+		tabla, err := cargarTablaDistancias(objetivo, errores)
+		if err != nil {
+			return nil, err
+		}
+		return tabla.Evaluar, nil
+	}
+	return nil, fmt.Errorf("heurística %q desconocida (manhattan, conflicto, pdb o exacta)", nombre)
+}
+
+func cargarTablaDistancias(objetivo puzzle.Tablero, errores io.Writer) (*puzzle.TablaDistancias, error) {
+	// cargarTablaDistancias obtiene la tabla de distancias del objetivo desde la caché en disco
+	// o la construye, informando por errores, como la base de patrones en construirHeuristica.
+	aviso := avisoConstruccion(errores, fmt.Sprintf("tabla de distancias %dx%d", objetivo.Filas(), objetivo.Columnas()))
+	tabla, err := puzzle.ObtenerTablaDistancias(objetivo, aviso)
+	if tabla == nil {
+		return nil, err
+	}
+	if err != nil {
+		fmt.Fprintf(errores, "advertencia: %v\n", err)
+	}
+	return tabla, nil
+}
+
+func avisoConstruccion(errores io.Writer, descripcion string) func(float64) {
+	// avisoConstruccion retorna una función de progreso que informa por errores, una sola vez,
+	// que la estructura descrita se está construyendo. Al leerse de la caché el avance llega
+	// directamente a 1, por lo que no se informa nada.
+	avisado := false
+	return func(avance float64) {
+		if !avisado && avance < 1 {
+			fmt.Fprintf(errores, "construyendo %s...\n", descripcion)
+			avisado = true
+		}
+	}
+}
+
+func tablaMezcla(objetivo puzzle.Tablero, errores io.Writer) (*puzzle.TablaDistancias, error) {
+	// tablaMezcla obtiene la tabla de distancias para puzzle.MezclarDistancia si el tablero la
+	// admite, una sola vez para todos los tableros generados; en tableros mayores retorna nil.
+	if objetivo.Tamano() > puzzle.MaxCeldasTablaDistancias {
+		return nil, nil
+	}
+	return cargarTablaDistancias(objetivo, errores)
+}
+
+func buscar(ctx context.Context, algoritmo string, inicial, objetivo puzzle.Tablero, heuristica puzzle.Heuristica, progreso func(puzzle.Progreso)) (puzzle.Resultado, error) {
+	// buscar ejecuta el algoritmo indicado (ya normalizado) con la heurística dada.
+	switch algoritmo {
+	case algoritmoAEstrella:
+		return puzzle.BusquedaAEstrella(ctx, inicial, objetivo, heuristica, progreso)
+	case algoritmoIDAEstrella:
+		return puzzle.BusquedaIDAEstrella(ctx, inicial, objetivo, heuristica, progreso)
+	default:
+		return puzzle.BusquedaAnchura(ctx, inicial, objetivo, progreso)
+	}
+}
+
+func contexto(limite time.Duration) (context.Context, context.CancelFunc) {
+	// contexto crea el contexto de una búsqueda: se cancela con Ctrl+C y, si limite es mayor
+	// que cero, al agotarse el tiempo máximo.
+	ctx, detener := signal.NotifyContext(context.Background(), os.Interrupt)
+	if limite <= 0 {
+		return ctx, detener
+	}
+	ctx, cancelar := context.WithTimeout(ctx, limite)
+	return ctx, func() {
+		cancelar()
+		detener()
+	}
+}
+
+func generador(semilla string, errores io.Writer) (*rand.Rand, error) {
+	// generador crea el generador aleatorio de una orden con la semilla de -semilla (cualquier
+	// entero de 64 bits sin signo, incluido 0). Si no se indica, elige una a partir del reloj y la
+	// informa en la salida de errores para poder repetir la ejecución.
+	if semilla == "" {
+		elegida := uint64(time.Now().UnixNano())
+		fmt.Fprintf(errores, "semilla: %d\n", elegida)
+		return puzzle.NuevoGenerador(elegida), nil
+	}
+	valor, err := strconv.ParseUint(strings.TrimSpace(semilla), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("semilla %q inválida (entero no negativo)", semilla)
+	}
+	return puzzle.NuevoGenerador(valor), nil
+}
+
+func validarFormato(formato string) error {
+	// validarFormato comprueba el valor de -formato.
+	if formato != "texto" && formato != "json" {
+		return fmt.Errorf("formato %q desconocido (texto o json)", formato)
+	}
+	return nil
+}
+
+func escribirJSON(w io.Writer, valor any) error {
+	// escribirJSON imprime el valor como JSON con sangría.
+	codificador := json.NewEncoder(w)
+	codificador.SetIndent("", "  ")
+	return codificador.Encode(valor)
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"puzzle-solver/puzzle"
+)
+
+func TestDimensiones(t *testing.T) {
+	// Las dimensiones no indicadas se deducen del número de valores.
+	casos := []struct {
+		valores, filas, columnas int
+		wantFilas, wantColumnas  int
+		wantErr                  bool
+	}{
+		{9, 0, 0, 3, 3, false},
+		{16, 0, 0, 4, 4, false},
+		{25, 0, 0, 5, 5, false},
+		{4, 0, 0, 2, 2, false},
+		{12, 3, 0, 3, 4, false},
+		{12, 0, 3, 4, 3, false},
+		{6, 2, 3, 2, 3, false},
+		{12, 0, 0, 0, 0, true}, // No es cuadrado: hay que indicar las dimensiones
+		{7, 0, 0, 0, 0, true},
+	}
+	for _, c := range casos {
+		filas, columnas, err := dimensiones(c.valores, c.filas, c.columnas)
+		if (err != nil) != c.wantErr || filas != c.wantFilas || columnas != c.wantColumnas {
+			t.Errorf("dimensiones(%d, %d, %d) = %d, %d, %v; se esperaba %d, %d (error %v)",
+				c.valores, c.filas, c.columnas, filas, columnas, err, c.wantFilas, c.wantColumnas, c.wantErr)
+		}
+	}
+}
+
+func TestLeerObjetivo(t *testing.T) {
+	// -objetivo acepta los nombres predefinidos o un tablero como texto de las mismas dimensiones.
+	personalizado, _ := puzzle.ParsearTablero(2, 3, "0 1 2 3 4 5")
+	casos := []struct {
+		objetivo string
+		want     puzzle.Tablero
+		wantErr  bool
+	}{
+		{"", puzzle.TableroObjetivo(2, 3), false},
+		{"estandar", puzzle.TableroObjetivo(2, 3), false},
+		{"vacio-inicial", puzzle.ObjetivoVacioInicial(2, 3), false},
+		{"espiral", puzzle.ObjetivoEspiral(2, 3), false},
+		{"0 1 2 3 4 5", personalizado, false},
+		{"0 1 2 3 4 5 6 7 8", puzzle.Tablero{}, true}, // Otras dimensiones
+		{"1 1 2 3 4 5", puzzle.Tablero{}, true},       // Valor repetido
+		{"diagonal", puzzle.Tablero{}, true},
+	}
+	for _, c := range casos {
+		o := opcionesTablero{objetivo: c.objetivo}
+		got, err := o.leerObjetivo(2, 3)
+		if (err != nil) != c.wantErr || (err == nil && got != c.want) {
+			t.Errorf("leerObjetivo(%q) = %v, %v", c.objetivo, got, err)
+		}
+	}
+}
+
+func TestGenerador(t *testing.T) {
+	// Sin -semilla se elige una y se informa; cualquier semilla indicada, incluida 0, repite la generación.
+	var errores bytes.Buffer
+	if _, err := generador("", &errores); err != nil || !strings.HasPrefix(errores.String(), "semilla: ") {
+		t.Fatalf("generador(\"\") informó %q, %v", errores.String(), err)
+	}
+
+	objetivo := puzzle.TableroObjetivo(3, 3)
+	mezclar := func(semilla string) puzzle.Tablero {
+		var errores bytes.Buffer
+		rng, err := generador(semilla, &errores)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if errores.Len() != 0 {
+			t.Fatalf("generador(%q) no debería informar la semilla indicada: %q", semilla, errores.String())
+		}
+		return puzzle.MezclarUniforme(objetivo, rng)
+	}
+	if mezclar("0") != mezclar("0") || mezclar(" 42 ") != mezclar("42") {
+		t.Fatal("la misma semilla produjo tableros distintos")
+	}
+	if mezclar("0") == mezclar("42") {
+		t.Fatal("las semillas 0 y 42 produjeron el mismo tablero")
+	}
+
+	for _, invalida := range []string{"-1", "x", "1.5", "18446744073709551616"} {
+		if _, err := generador(invalida, &errores); err == nil {
+			t.Errorf("generador(%q) debería fallar", invalida)
+		}
+	}
+}
+
+func TestValidarFormato(t *testing.T) {
+	// Solo se aceptan los formatos texto y json.
+	for formato, valido := range map[string]bool{"texto": true, "json": true, "csv": false, "": false, "JSON": false} {
+		if err := validarFormato(formato); (err == nil) != valido {
+			t.Errorf("validarFormato(%q) = %v", formato, err)
+		}
+	}
+}
+
+func TestEjecutarCodigoSalida(t *testing.T) {
+	// Ejecutar retorna 0 si tuvo éxito, 1 ante un error, 2 ante argumentos incorrectos y 3 si
+	// check determina que el tablero no tiene solución.
+	casos := []struct {
+		nombre string
+		args   []string
+		codigo int
+	}{
+		{"sin argumentos", nil, 2},
+		{"subcomando desconocido", []string{"resolver"}, 2},
+		{"ayuda general", []string{"help"}, 0},
+		{"ayuda de un subcomando", []string{"solve", "-h"}, 0},
+		{"opción desconocida", []string{"solve", "-tablero", "1 2 3 4 5 6 7 8 0", "-rapido"}, 2},
+		{"argumento sobrante", []string{"check", "-tablero", "1 2 3 4 5 6 7 8 0", "extra"}, 2},
+		{"valor de opción inválido", []string{"shuffle", "-cantidad", "muchos"}, 2},
+		{"tablero inválido", []string{"solve", "-tablero", "1 2 3 4 5 6 7 8 8"}, 1},
+		{"algoritmo desconocido", []string{"solve", "-tablero", "1 2 3 4 5 6 7 0 8", "-algoritmo", "dfs"}, 1},
+		{"semilla inválida", []string{"shuffle", "-semilla", "x"}, 1},
+		{"formato inválido", []string{"check", "-tablero", "1 2 3 4 5 6 7 8 0", "-formato", "csv"}, 1},
+		{"resuelve", []string{"solve", "-tablero", "1 2 3 4 5 6 7 0 8"}, 0},
+		{"mezcla con semilla 0", []string{"shuffle", "-semilla", "0"}, 0},
+		{"check resoluble", []string{"check", "-tablero", "1 2 3 4 5 6 0 7 8"}, 0},
+		{"check irresoluble", []string{"check", "-tablero", "2 1 3 4 5 6 7 8 0"}, 3},
+		{"check irresoluble en json", []string{"check", "-tablero", "2 1 3 4 5 6 7 8 0", "-formato", "json"}, 3},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			var salida, errores bytes.Buffer
+			if codigo := Ejecutar(c.args, &salida, &errores); codigo != c.codigo {
+				t.Fatalf("Ejecutar(%q) = %d, se esperaba %d\n%s", c.args, codigo, c.codigo, errores.String())
+			}
+		})
+	}
+}
+
+func TestCheckImprimeVeredicto(t *testing.T) {
+	// El veredicto de un tablero irresoluble se imprime completo aunque el código de salida sea 3,
+	// sin un mensaje de error adicional.
+	var salida, errores bytes.Buffer
+	Ejecutar([]string{"check", "-tablero", "2 1 3 4 5 6 7 8 0"}, &salida, &errores)
+	if !strings.Contains(salida.String(), "Resoluble:") || !strings.Contains(salida.String(), "no") {
+		t.Fatalf("salida sin veredicto: %q", salida.String())
+	}
+	if errores.Len() != 0 {
+		t.Fatalf("salida de errores inesperada: %q", errores.String())
+	}
+}
+
+func TestBenchHeuristicasSoloInformados(t *testing.T) {
+	// Las heurísticas solo se construyen si algún algoritmo informado las usa: con solo anchura
+	// ni siquiera se valida su nombre.
+	casos := []struct {
+		nombre     string
+		algoritmos string
+		codigo     int
+	}{
+		{"solo anchura", "anchura", 0},
+		{"sinónimo de anchura", "bfs", 0},
+		{"con un algoritmo informado", "anchura,aestrella", 1},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			var salida, errores bytes.Buffer
+			args := []string{"bench", "-tablero", "1 2 3 4 5 6 0 7 8", "-algoritmos", c.algoritmos, "-heuristicas", "inexistente"}
+			if codigo := Ejecutar(args, &salida, &errores); codigo != c.codigo {
+				t.Fatalf("Ejecutar(%q) = %d, se esperaba %d\n%s", args, codigo, c.codigo, errores.String())
+			}
+		})
+	}
+}
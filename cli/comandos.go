@@ -0,0 +1,726 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"puzzle-solver/benchmark"
+	"puzzle-solver/puzzle"
+)
+
+// salidaSolucion es el resultado de solve en formato JSON.
+type salidaSolucion struct {
+	Tablero      string              `json:"tablero"`
+	Objetivo     string              `json:"objetivo"`
+	Filas        int                 `json:"filas"`
+	Columnas     int                 `json:"columnas"`
+	Algoritmo    string              `json:"algoritmo"`
+	Heuristica   string              `json:"heuristica,omitempty"`
+	Pasos        int                 `json:"pasos"`
+	Movimientos  []string            `json:"movimientos"`
+	Iteraciones  int                 `json:"iteraciones,omitempty"`
+	Cotas        []int               `json:"cotas,omitempty"`
+	Estadisticas puzzle.Estadisticas `json:"estadisticas"`
+}
+
+func ejecutarSolve(args []string, salida, errores io.Writer) error {
+	// ejecutarSolve resuelve un tablero con el algoritmo y la heurística indicados e imprime
+	// la secuencia de movimientos y las estadísticas de la búsqueda.
+	fs := nuevoFlagSet("solve", errores)
+	var tablero opcionesTablero
+	tablero.registrar(fs, true)
+	algoritmo := fs.String("algoritmo", algoritmoAEstrella, "algoritmo: aestrella, idaestrella o anchura (también astar, idastar, bfs)")
+	nombreHeuristica := fs.String("heuristica", heuristicaConflictoLineal, "heurística para aestrella e idaestrella: manhattan, conflicto, pdb o exacta (hasta 3x3)")
+	formato := fs.String("formato", "texto", "formato de salida: texto o json")
+	limite := fs.Duration("limite", 0, "tiempo máximo de búsqueda, por ejemplo 30s (0 sin límite)")
+	verProgreso := fs.Bool("progreso", false, "mostrar el progreso de la búsqueda en la salida de errores")
+	if err := analizar(fs, args); err != nil {
+		return err
+	}
+
+	if err := validarFormato(*formato); err != nil {
+		return err
+	}
+	nombre, err := normalizarAlgoritmo(*algoritmo)
+	if err != nil {
+		return err
+	}
+	inicial, objetivo, err := tablero.leer()
+	if err != nil {
+		return err
+	}
+	if analisis := puzzle.AnalizarResolubilidad(inicial, objetivo); !analisis.Resoluble {
+		return fmt.Errorf("el tablero no es resoluble: %s", analisis.Explicacion)
+	}
+
+	var heuristica puzzle.Heuristica
+	if nombre != algoritmoAnchura {
+		if heuristica, err = construirHeuristica(*nombreHeuristica, objetivo, errores); err != nil {
+			return err
+		}
+	} else {
+		*nombreHeuristica = ""
+	}
+
+	var progreso func(puzzle.Progreso)
+	if *verProgreso {
+		progreso = func(p puzzle.Progreso) {
+			fmt.Fprintf(errores, "expandidos=%d frontera=%d f=%d profundidad=%d tiempo=%s\n",
+				p.Expandidos, p.Frontera, p.MejorF, p.Profundidad, p.Transcurrido.Round(time.Millisecond))
+		}
+	}
+
+	ctx, cancelar := contexto(*limite)
+	defer cancelar()
+	resultado, err := buscar(ctx, nombre, inicial, objetivo, heuristica, progreso)
+	if err != nil {
+		return fmt.Errorf("búsqueda interrumpida tras %d nodos expandidos: %w", resultado.Estadisticas.NodosExpandidos, err)
+	}
+	if len(resultado.Camino) == 0 {
+		return errors.New("no se encontró solución")
+	}
+
+	movimientos := make([]string, 0, len(resultado.Camino)-1)
+	for _, estado := range resultado.Camino[1:] {
+		movimientos = append(movimientos, estado.Accion)
+	}
+
+	if *formato == "json" {
+		return escribirJSON(salida, salidaSolucion{
+			Tablero:      inicial.String(),
+			Objetivo:     objetivo.String(),
+			Filas:        inicial.Filas(),
+			Columnas:     inicial.Columnas(),
+			Algoritmo:    nombre,
+			Heuristica:   *nombreHeuristica,
+			Pasos:        len(movimientos),
+			Movimientos:  movimientos,
+			Iteraciones:  resultado.Iteraciones,
+			Cotas:        resultado.Cotas,
+			Estadisticas: resultado.Estadisticas,
+		})
+	}
+
+	tw := tabwriter.NewWriter(salida, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Tablero:\t%s (%dx%d)\n", inicial, inicial.Filas(), inicial.Columnas())
+	fmt.Fprintf(tw, "Objetivo:\t%s\n", objetivo)
+	if *nombreHeuristica != "" {
+		fmt.Fprintf(tw, "Algoritmo:\t%s con %s\n", nombre, *nombreHeuristica)
+	} else {
+		fmt.Fprintf(tw, "Algoritmo:\t%s\n", nombre)
+	}
+	fmt.Fprintf(tw, "Pasos:\t%d\n", len(movimientos))
+	fmt.Fprintf(tw, "Movimientos:\t%s\n", strings.Join(movimientos, " "))
+	if resultado.Iteraciones > 0 {
+		fmt.Fprintf(tw, "Iteraciones:\t%d (cotas %v)\n", resultado.Iteraciones, resultado.Cotas)
+	}
+	escribirEstadisticas(tw, resultado.Estadisticas)
+	return tw.Flush()
+}
+
+func escribirEstadisticas(tw *tabwriter.Writer, e puzzle.Estadisticas) {
+	// escribirEstadisticas imprime las estadísticas de una búsqueda en formato texto.
+	fmt.Fprintf(tw, "Nodos expandidos:\t%d\n", e.NodosExpandidos)
+	fmt.Fprintf(tw, "Nodos generados:\t%d\n", e.NodosGenerados)
+	fmt.Fprintf(tw, "Frontera máxima:\t%d\n", e.MaxFrontera)
+	fmt.Fprintf(tw, "Lista cerrada máxima:\t%d\n", e.MaxCerrada)
+	fmt.Fprintf(tw, "Duplicados podados:\t%d\n", e.DuplicadosPodados)
+	fmt.Fprintf(tw, "Factor de ramificación:\t%.3f\n", e.FactorRamificacion)
+	fmt.Fprintf(tw, "Memoria estimada:\t%.1f KB\n", float64(e.MemoriaEstimada)/1024)
+	fmt.Fprintf(tw, "Tiempo:\t%s\n", e.Tiempo.Round(time.Microsecond))
+}
+
+// salidaMezcla describe un tablero generado por shuffle en formato JSON.
+type salidaMezcla struct {
+	Tablero  string `json:"tablero"`
+	Filas    int    `json:"filas"`
+	Columnas int    `json:"columnas"`
+	Optimo   int    `json:"optimo,omitempty"` // Longitud óptima de la solución, solo con -profundidad
+}
+
+// Modos de mezcla de shuffle (-modo).
+const (
+	modoCaminata = "caminata" // Movimientos aleatorios desde el objetivo
+	modoUniforme = "uniforme" // Permutación uniforme entre todos los tableros resolubles
+)
+
+func ejecutarShuffle(args []string, salida, errores io.Writer) error {
+	// ejecutarShuffle genera tableros aplicando movimientos aleatorios desde el objetivo, eligiéndolos
+	// uniformemente entre todos los resolubles o con una solución óptima de exactamente -profundidad
+	// movimientos, e imprime uno por línea, listos para usarse con -archivo o como conjunto de pruebas.
+	fs := nuevoFlagSet("shuffle", errores)
+	var tablero opcionesTablero
+	tablero.registrar(fs, false)
+	modo := fs.String("modo", modoCaminata, "modo de mezcla: caminata (movimientos aleatorios) o uniforme (todos los resolubles igual de probables)")
+	pasos := fs.Int("pasos", 150, "movimientos aleatorios aplicados desde el objetivo en el modo caminata")
+	profundidad := fs.Int("profundidad", -1, "generar tableros cuya solución óptima tenga exactamente estos movimientos (ignora -pasos; hasta 3x3 se eligen uniformemente con la tabla de distancias)")
+	semilla := fs.String("semilla", "", "semilla para repetir la generación (si se omite se elige una y se informa)")
+	cantidad := fs.Int("cantidad", 1, "número de tableros a generar")
+	formato := fs.String("formato", "texto", "formato de salida: texto o json")
+	if err := analizar(fs, args); err != nil {
+		return err
+	}
+
+	if err := validarFormato(*formato); err != nil {
+		return err
+	}
+	if *pasos < 0 || *cantidad < 1 {
+		return errors.New("-pasos debe ser mayor o igual a 0 y -cantidad mayor que 0")
+	}
+	if *modo != modoCaminata && *modo != modoUniforme {
+		return fmt.Errorf("modo %q desconocido (caminata o uniforme)", *modo)
+	}
+	if *modo == modoUniforme && *profundidad >= 0 {
+		return errors.New("-profundidad no puede combinarse con -modo uniforme")
+	}
+	filas, columnas, err := tablero.dimensionesGeneracion()
+	if err != nil {
+		return err
+	}
+	objetivo, err := tablero.leerObjetivo(filas, columnas)
+	if err != nil {
+		return err
+	}
+
+	rng, err := generador(*semilla, errores)
+	if err != nil {
+		return err
+	}
+	tableros := make([]salidaMezcla, *cantidad)
+	if *profundidad >= 0 {
+		tabla, err := tablaMezcla(objetivo, errores)
+		if err != nil {
+			return err
+		}
+		ctx, cancelar := contexto(0)
+		defer cancelar()
+		for i := range tableros {
+			mezclado, err := puzzle.MezclarDistancia(ctx, objetivo, *profundidad, tabla, rng)
+			if err != nil {
+				return err
+			}
+			tableros[i] = salidaMezcla{Tablero: mezclado.String(), Filas: filas, Columnas: columnas, Optimo: *profundidad}
+		}
+	} else {
+		for i := range tableros {
+			var mezclado puzzle.Tablero
+			if *modo == modoUniforme {
+				mezclado = puzzle.MezclarUniforme(objetivo, rng)
+			} else {
+				mezclado = puzzle.MezclarAleatorio(objetivo, *pasos, rng)
+			}
+			tableros[i] = salidaMezcla{Tablero: mezclado.String(), Filas: filas, Columnas: columnas}
+		}
+	}
+
+	if *formato == "json" {
+		return escribirJSON(salida, tableros)
+	}
+	for _, t := range tableros {
+		if *profundidad >= 0 {
+			fmt.Fprintf(salida, "%s = %d\n", t.Tablero, t.Optimo)
+		} else {
+			fmt.Fprintln(salida, t.Tablero)
+		}
+	}
+	return nil
+}
+
+func ejecutarBench(args []string, salida, errores io.Writer) error {
+	// ejecutarBench ejecuta cada combinación de algoritmo y heurística sobre una suite de tableros
+	// (un solo tablero, un archivo con uno por línea, tableros generados a varias profundidades
+	// o elegidos uniformemente entre todos los resolubles)
+	// y presenta una tabla comparativa. Los resultados por instancia y agregados pueden exportarse
+	// como CSV y JSON. Cada ejecución tiene un tiempo máximo propio.
+	fs := nuevoFlagSet("bench", errores)
+	var tablero opcionesTablero
+	tablero.registrar(fs, true)
+	var suite opcionesSuite
+	suite.registrar(fs)
+	algoritmos := fs.String("algoritmos", "aestrella,idaestrella,anchura", "algoritmos a comparar, separados por comas")
+	heuristicas := fs.String("heuristicas", "manhattan,conflicto,pdb", "heurísticas a comparar en los algoritmos informados")
+	limite := fs.Duration("limite", 30*time.Second, "tiempo máximo por ejecución (0 sin límite)")
+	formato := fs.String("formato", "texto", "formato de salida: texto o json")
+	rutaCSV := fs.String("csv", "", "archivo CSV con los resultados por instancia")
+	rutaResumen := fs.String("resumen-csv", "", "archivo CSV con los resultados agregados por combinación")
+	rutaJSON := fs.String("json", "", "archivo JSON con los resultados por instancia y agregados")
+	if err := analizar(fs, args); err != nil {
+		return err
+	}
+
+	if err := validarFormato(*formato); err != nil {
+		return err
+	}
+	instancias, objetivo, err := suite.leer(&tablero, errores)
+	if err != nil {
+		return err
+	}
+
+	nombresAlgoritmo := strings.Split(*algoritmos, ",")
+	informados := false
+	for i, nombre := range nombresAlgoritmo {
+		if nombresAlgoritmo[i], err = normalizarAlgoritmo(nombre); err != nil {
+			return err
+		}
+		informados = informados || nombresAlgoritmo[i] != algoritmoAnchura
+	}
+
+	// Construir las heurísticas una sola vez (la base de patrones puede tardar) y solo si algún
+	// algoritmo informado va a usarlas
+	nombresHeuristica := strings.Split(*heuristicas, ",")
+	funciones := map[string]puzzle.Heuristica{}
+	if informados {
+		for _, nombre := range nombresHeuristica {
+			if funciones[nombre], err = construirHeuristica(nombre, objetivo, errores); err != nil {
+				return err
+			}
+		}
+	}
+
+	combinaciones := []benchmark.Combinacion{}
+	for _, algoritmo := range nombresAlgoritmo {
+		nombres := nombresHeuristica
+		if algoritmo == algoritmoAnchura {
+			nombres = []string{""} // BFS no usa heurística
+		}
+		for _, nombreHeuristica := range nombres {
+			heuristica := funciones[nombreHeuristica]
+			combinaciones = append(combinaciones, benchmark.Combinacion{
+				Algoritmo:  algoritmo,
+				Heuristica: nombreHeuristica,
+				Resolver: func(ctx context.Context, inicial puzzle.Tablero) (puzzle.Resultado, error) {
+					return buscar(ctx, algoritmo, inicial, objetivo, heuristica, nil)
+				},
+			})
+		}
+	}
+
+	ctx, cancelar := contexto(0)
+	defer cancelar()
+	mediciones, errEjecucion := benchmark.Ejecutar(ctx, instancias, combinaciones, *limite, func(hechas, total int, m benchmark.Medicion) {
+		resultado := fmt.Sprintf("%d pasos", m.Pasos)
+		if !m.Completado {
+			resultado = m.Error
+		}
+		fmt.Fprintf(errores, "[%d/%d] instancia %d, %s: %s (%s)\n", hechas, total, m.Instancia,
+			strings.TrimSpace(m.Algoritmo+" "+m.Heuristica), resultado, m.Estadisticas.Tiempo.Round(time.Microsecond))
+	})
+	informe := benchmark.Informe{Mediciones: mediciones, Resumen: benchmark.Resumir(mediciones)}
+
+	// Exportar lo medido aunque la ejecución se haya interrumpido con Ctrl+C
+	if err := exportar(*rutaCSV, func(w io.Writer) error { return benchmark.EscribirCSV(w, informe.Mediciones) }); err != nil {
+		return err
+	}
+	if err := exportar(*rutaResumen, func(w io.Writer) error { return benchmark.EscribirResumenCSV(w, informe.Resumen) }); err != nil {
+		return err
+	}
+	if err := exportar(*rutaJSON, func(w io.Writer) error { return benchmark.EscribirJSON(w, informe) }); err != nil {
+		return err
+	}
+
+	switch {
+	case *formato == "json":
+		err = benchmark.EscribirJSON(salida, informe)
+	case len(instancias) == 1:
+		err = escribirMediciones(salida, informe.Mediciones)
+	default:
+		err = escribirResumen(salida, informe.Resumen)
+	}
+	if errEjecucion != nil {
+		return fmt.Errorf("comparativa interrumpida: %w", errEjecucion)
+	}
+	return err
+}
+
+// opcionesSuite agrupa las opciones de bench que eligen la suite de tableros.
+type opcionesSuite struct {
+	archivo        string // -suite: archivo con un tablero por línea
+	profundidades  string // -profundidades: lista de profundidades exactas a generar
+	porProfundidad int    // -por-profundidad: tableros generados por cada profundidad
+	uniformes      int    // -uniformes: tableros generados con distribución uniforme
+	semilla        string // -semilla: semilla de la suite generada (vacía para elegir una)
+}
+
+func (o *opcionesSuite) registrar(fs *flag.FlagSet) {
+	// registrar declara las opciones de la suite en el conjunto de flags de bench.
+	fs.StringVar(&o.archivo, "suite", "", "archivo con un tablero por línea (opcionalmente \"tablero = óptimo\")")
+	fs.StringVar(&o.profundidades, "profundidades", "", "generar la suite a estas profundidades, por ejemplo 10,20,30")
+	fs.IntVar(&o.porProfundidad, "por-profundidad", 5, "tableros generados por cada profundidad")
+	fs.IntVar(&o.uniformes, "uniformes", 0, "generar la suite con este número de tableros uniformes entre todos los resolubles")
+	fs.StringVar(&o.semilla, "semilla", "", "semilla de la suite generada para repetirla (si se omite se elige una y se informa)")
+}
+
+func (o *opcionesSuite) leer(tablero *opcionesTablero, errores io.Writer) ([]benchmark.Instancia, puzzle.Tablero, error) {
+	// leer obtiene la suite de bench: de -suite, generada con -profundidades o -uniformes o,
+	// si no se indica ninguna, el tablero único de -tablero o -archivo. La suite generada usa la
+	// semilla indicada e informa en errores la elegida si no se indicó ninguna.
+	var objetivo puzzle.Tablero
+	origenes := 0
+	for _, indicado := range []bool{o.archivo != "", o.profundidades != "", o.uniformes != 0} {
+		if indicado {
+			origenes++
+		}
+	}
+	switch {
+	case origenes > 1:
+		return nil, objetivo, errors.New("indica solo una de -suite, -profundidades o -uniformes")
+
+	case o.archivo != "":
+		archivo, err := os.Open(o.archivo)
+		if err != nil {
+			return nil, objetivo, err
+		}
+		defer archivo.Close()
+		instancias, err := benchmark.LeerSuite(archivo, func(texto string) (puzzle.Tablero, error) {
+			filas, columnas, err := dimensiones(contarValores(texto), tablero.filas, tablero.columnas)
+			if err != nil {
+				return puzzle.Tablero{}, err
+			}
+			return puzzle.ParsearTablero(filas, columnas, texto)
+		})
+		if err != nil {
+			return nil, objetivo, err
+		}
+		filas, columnas := instancias[0].Tablero.Filas(), instancias[0].Tablero.Columnas()
+		for i, instancia := range instancias {
+			if instancia.Tablero.Filas() != filas || instancia.Tablero.Columnas() != columnas {
+				return nil, objetivo, fmt.Errorf("tablero %d: todos los tableros de la suite deben ser de %dx%d", i+1, filas, columnas)
+			}
+		}
+		objetivo, err = tablero.leerObjetivo(filas, columnas)
+		return instancias, objetivo, err
+
+	case o.profundidades != "":
+		if o.porProfundidad < 1 {
+			return nil, objetivo, errors.New("-por-profundidad debe ser mayor que 0")
+		}
+		lista := []int{}
+		for _, texto := range strings.Split(o.profundidades, ",") {
+			profundidad, err := strconv.Atoi(strings.TrimSpace(texto))
+			if err != nil || profundidad < 0 {
+				return nil, objetivo, fmt.Errorf("profundidad inválida %q", texto)
+			}
+			lista = append(lista, profundidad)
+		}
+		filas, columnas, err := tablero.dimensionesGeneracion()
+		if err != nil {
+			return nil, objetivo, err
+		}
+		if objetivo, err = tablero.leerObjetivo(filas, columnas); err != nil {
+			return nil, objetivo, err
+		}
+		tabla, err := tablaMezcla(objetivo, errores)
+		if err != nil {
+			return nil, objetivo, err
+		}
+		rng, err := generador(o.semilla, errores)
+		if err != nil {
+			return nil, objetivo, err
+		}
+		ctx, cancelar := contexto(0)
+		defer cancelar()
+		instancias, err := benchmark.GenerarSuite(ctx, objetivo, lista, o.porProfundidad, tabla, rng)
+		return instancias, objetivo, err
+
+	case o.uniformes != 0:
+		if o.uniformes < 0 {
+			return nil, objetivo, errors.New("-uniformes debe ser mayor que 0")
+		}
+		filas, columnas, err := tablero.dimensionesGeneracion()
+		if err != nil {
+			return nil, objetivo, err
+		}
+		if objetivo, err = tablero.leerObjetivo(filas, columnas); err != nil {
+			return nil, objetivo, err
+		}
+		rng, err := generador(o.semilla, errores)
+		if err != nil {
+			return nil, objetivo, err
+		}
+		return benchmark.GenerarSuiteUniforme(objetivo, o.uniformes, rng), objetivo, nil
+	}
+
+	inicial, objetivo, err := tablero.leer()
+	if err != nil {
+		return nil, objetivo, err
+	}
+	if analisis := puzzle.AnalizarResolubilidad(inicial, objetivo); !analisis.Resoluble {
+		return nil, objetivo, fmt.Errorf("el tablero no es resoluble: %s", analisis.Explicacion)
+	}
+	return []benchmark.Instancia{{Tablero: inicial, Optimo: -1}}, objetivo, nil
+}
+
+func exportar(ruta string, escribir func(io.Writer) error) error {
+	// exportar crea el archivo indicado y escribe en él el informe; no hace nada si la ruta está vacía.
+	if ruta == "" {
+		return nil
+	}
+	archivo, err := os.Create(ruta)
+	if err != nil {
+		return err
+	}
+	if err := escribir(archivo); err != nil {
+		archivo.Close()
+		return err
+	}
+	return archivo.Close()
+}
+
+func escribirMediciones(salida io.Writer, mediciones []benchmark.Medicion) error {
+	// escribirMediciones imprime una fila por combinación con las estadísticas de la búsqueda.
+	tw := tabwriter.NewWriter(salida, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "ALGORITMO\tHEURÍSTICA\tPASOS\tEXPANDIDOS\tGENERADOS\tFRONTERA\tCERRADA\tB*\tMEMORIA KB\tTIEMPO ms\t")
+	for _, m := range mediciones {
+		pasos := fmt.Sprint(m.Pasos)
+		if !m.Completado {
+			pasos = "límite"
+		}
+		e := m.Estadisticas
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%.3f\t%.1f\t%.1f\t\n",
+			m.Algoritmo, m.Heuristica, pasos, e.NodosExpandidos, e.NodosGenerados, e.MaxFrontera, e.MaxCerrada,
+			e.FactorRamificacion, float64(e.MemoriaEstimada)/1024, float64(e.Tiempo.Microseconds())/1000)
+	}
+	return tw.Flush()
+}
+
+func escribirResumen(salida io.Writer, resumenes []benchmark.Resumen) error {
+	// escribirResumen imprime una fila por combinación con las métricas agregadas de la suite.
+	tw := tabwriter.NewWriter(salida, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "ALGORITMO\tHEURÍSTICA\tRESUELTAS\tÓPTIMAS\tNODOS MEDIA\tNODOS MEDIANA\tNODOS MÁX\tms MEDIA\tms MEDIANA\tms MÁX\tPASOS MEDIA\tBRECHA MÁX\t")
+	for _, r := range resumenes {
+		fmt.Fprintf(tw, "%s\t%s\t%d/%d\t%d\t%.0f\t%.0f\t%.0f\t%.1f\t%.1f\t%.1f\t%.2f\t%.0f\t\n",
+			r.Algoritmo, r.Heuristica, r.Resueltas, r.Instancias, r.Optimas, r.Nodos.Media, r.Nodos.Mediana, r.Nodos.Maximo,
+			r.TiempoMs.Media, r.TiempoMs.Mediana, r.TiempoMs.Maximo, r.Pasos.Media, r.Brecha.Maximo)
+	}
+	return tw.Flush()
+}
+
+// salidaVerificacion es el resultado de check en formato JSON.
+type salidaVerificacion struct {
+	Tablero           string `json:"tablero"`
+	Objetivo          string `json:"objetivo"`
+	Resoluble         bool   `json:"resoluble"`
+	Inversiones       int    `json:"inversiones"`
+	ColumnasPares     bool   `json:"columnas_pares"`
+	FilaVacio         int    `json:"fila_vacio"`
+	FilaVacioObjetivo int    `json:"fila_vacio_objetivo"`
+	Paridad           int    `json:"paridad"`
+	Explicacion       string `json:"explicacion"`
+}
+
+func ejecutarCheck(args []string, salida, errores io.Writer) error {
+	// ejecutarCheck verifica la resolubilidad del tablero respecto al objetivo y explica
+	// el veredicto mediante la paridad de inversiones. Las filas se numeran desde 1.
+	// Si el tablero no tiene solución retorna errIrresoluble tras imprimir el veredicto, para
+	// que los scripts puedan distinguirlo por el código de salida.
+	fs := nuevoFlagSet("check", errores)
+	var tablero opcionesTablero
+	tablero.registrar(fs, true)
+	formato := fs.String("formato", "texto", "formato de salida: texto o json")
+	if err := analizar(fs, args); err != nil {
+		return err
+	}
+
+	if err := validarFormato(*formato); err != nil {
+		return err
+	}
+	inicial, objetivo, err := tablero.leer()
+	if err != nil {
+		return err
+	}
+	analisis := puzzle.AnalizarResolubilidad(inicial, objetivo)
+
+	veredicto := func(err error) error {
+		if err == nil && !analisis.Resoluble {
+			return errIrresoluble
+		}
+		return err
+	}
+
+	if *formato == "json" {
+		return veredicto(escribirJSON(salida, salidaVerificacion{
+			Tablero:           inicial.String(),
+			Objetivo:          objetivo.String(),
+			Resoluble:         analisis.Resoluble,
+			Inversiones:       analisis.Inversiones,
+			ColumnasPares:     analisis.ColumnasPares,
+			FilaVacio:         analisis.FilaVacio + 1,
+			FilaVacioObjetivo: analisis.FilaVacioObjetivo + 1,
+			Paridad:           analisis.Paridad,
+			Explicacion:       analisis.Explicacion,
+		}))
+	}
+
+	resoluble := "sí"
+	if !analisis.Resoluble {
+		resoluble = "no"
+	}
+	tw := tabwriter.NewWriter(salida, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Tablero:\t%s (%dx%d)\n", inicial, inicial.Filas(), inicial.Columnas())
+	fmt.Fprintf(tw, "Objetivo:\t%s\n", objetivo)
+	fmt.Fprintf(tw, "Resoluble:\t%s\n", resoluble)
+	fmt.Fprintf(tw, "Inversiones:\t%d\n", analisis.Inversiones)
+	if analisis.ColumnasPares {
+		fmt.Fprintf(tw, "Fila del vacío:\t%d (objetivo: %d)\n", analisis.FilaVacio+1, analisis.FilaVacioObjetivo+1)
+	}
+	fmt.Fprintf(tw, "Paridad:\t%d\n", analisis.Paridad)
+	fmt.Fprintf(tw, "Explicación:\t%s\n", analisis.Explicacion)
+	return veredicto(tw.Flush())
+}
+
+// salidaTabla es el resultado de table en formato JSON.
+type salidaTabla struct {
+	Objetivo     string             `json:"objetivo"`
+	Filas        int                `json:"filas"`
+	Columnas     int                `json:"columnas"`
+	Estados      int                `json:"estados"`
+	Diametro     int                `json:"diametro"`
+	Histograma   []int              `json:"histograma"`    // Estados a cada distancia, desde 0
+	MasDificiles []string           `json:"mas_dificiles"` // Estados a la distancia máxima
+	Tablero      string             `json:"tablero,omitempty"`
+	Distancia    *int               `json:"distancia,omitempty"` // Distancia óptima de -tablero
+	Validacion   []salidaValidacion `json:"validacion,omitempty"`
+}
+
+// salidaValidacion compara una heurística con la distancia exacta en todos los estados.
+type salidaValidacion struct {
+	Heuristica      string  `json:"heuristica"`
+	Admisible       bool    `json:"admisible"`
+	Sobreestimados  int     `json:"sobreestimados"` // Estados con h(n) mayor que la distancia
+	Exactos         int     `json:"exactos"`        // Estados con h(n) igual a la distancia
+	ErrorMedio      float64 `json:"error_medio"`    // Media de distancia - h(n)
+	ProporcionMedia float64 `json:"proporcion_media"`
+}
+
+func ejecutarTable(args []string, salida, errores io.Writer) error {
+	// ejecutarTable enumera con una BFS todos los estados resolubles de un tablero de hasta 3x3
+	// desde el objetivo (ver puzzle.TablaDistancias) e imprime el histograma de distancias y las
+	// posiciones más difíciles. Opcionalmente consulta la distancia exacta de -tablero y valida
+	// heurísticas comparándolas con la distancia exacta en todo el espacio de estados.
+	fs := nuevoFlagSet("table", errores)
+	var tablero opcionesTablero
+	tablero.registrar(fs, true)
+	validar := fs.String("validar", "", "heurísticas a validar contra la distancia exacta, por ejemplo manhattan,conflicto,pdb")
+	formato := fs.String("formato", "texto", "formato de salida: texto o json")
+	if err := analizar(fs, args); err != nil {
+		return err
+	}
+
+	if err := validarFormato(*formato); err != nil {
+		return err
+	}
+	var inicial, objetivo puzzle.Tablero
+	var err error
+	consultar := tablero.tablero != "" || tablero.archivo != ""
+	if consultar {
+		if inicial, objetivo, err = tablero.leer(); err != nil {
+			return err
+		}
+		if analisis := puzzle.AnalizarResolubilidad(inicial, objetivo); !analisis.Resoluble {
+			return fmt.Errorf("el tablero no es resoluble: %s", analisis.Explicacion)
+		}
+	} else {
+		filas, columnas, err := tablero.dimensionesGeneracion()
+		if err != nil {
+			return err
+		}
+		if objetivo, err = tablero.leerObjetivo(filas, columnas); err != nil {
+			return err
+		}
+	}
+	tabla, err := cargarTablaDistancias(objetivo, errores)
+	if err != nil {
+		return err
+	}
+
+	histograma := tabla.Histograma()
+	resultado := salidaTabla{
+		Objetivo:   objetivo.String(),
+		Filas:      objetivo.Filas(),
+		Columnas:   objetivo.Columnas(),
+		Estados:    tabla.Estados(),
+		Diametro:   len(histograma) - 1,
+		Histograma: histograma,
+	}
+	for _, dificil := range tabla.ADistancia(resultado.Diametro) {
+		resultado.MasDificiles = append(resultado.MasDificiles, dificil.String())
+	}
+	if consultar {
+		distancia := tabla.Distancia(inicial)
+		resultado.Tablero, resultado.Distancia = inicial.String(), &distancia
+	}
+	if *validar != "" {
+		for _, nombre := range strings.Split(*validar, ",") {
+			heuristica, err := construirHeuristica(nombre, objetivo, errores)
+			if err != nil {
+				return err
+			}
+			resultado.Validacion = append(resultado.Validacion, validarHeuristica(nombre, heuristica, tabla))
+		}
+	}
+
+	if *formato == "json" {
+		return escribirJSON(salida, resultado)
+	}
+	tw := tabwriter.NewWriter(salida, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Objetivo:\t%s (%dx%d)\n", objetivo, objetivo.Filas(), objetivo.Columnas())
+	fmt.Fprintf(tw, "Estados:\t%d\n", resultado.Estados)
+	fmt.Fprintf(tw, "Diámetro:\t%d movimientos\n", resultado.Diametro)
+	if consultar {
+		fmt.Fprintf(tw, "Tablero:\t%s\n", inicial)
+		fmt.Fprintf(tw, "Distancia óptima:\t%d\n", *resultado.Distancia)
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "\tDISTANCIA\tESTADOS\tPORCENTAJE\t")
+	for distancia, estados := range histograma {
+		fmt.Fprintf(tw, "\t%d\t%d\t%.3f%%\t\n", distancia, estados, 100*float64(estados)/float64(resultado.Estados))
+	}
+	fmt.Fprintf(tw, "\nMás difíciles (%d movimientos):\n", resultado.Diametro)
+	for _, dificil := range resultado.MasDificiles {
+		fmt.Fprintf(tw, "  %s\n", dificil)
+	}
+	if len(resultado.Validacion) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "\tHEURÍSTICA\tADMISIBLE\tSOBREESTIMADOS\tEXACTOS\tERROR MEDIO\th/d MEDIA\t")
+		for _, v := range resultado.Validacion {
+			admisible := "sí"
+			if !v.Admisible {
+				admisible = "no"
+			}
+			fmt.Fprintf(tw, "\t%s\t%s\t%d\t%d\t%.2f\t%.3f\t\n", v.Heuristica, admisible, v.Sobreestimados, v.Exactos, v.ErrorMedio, v.ProporcionMedia)
+		}
+	}
+	return tw.Flush()
+}
+
+func validarHeuristica(nombre string, heuristica puzzle.Heuristica, tabla *puzzle.TablaDistancias) salidaValidacion {
+	// validarHeuristica evalúa la heurística en todos los estados de la tabla y la compara con la
+	// distancia exacta. La proporción h/d media excluye el objetivo (d = 0).
+	v := salidaValidacion{Heuristica: nombre}
+	errorTotal, proporcionTotal := 0, 0.0
+	tabla.Recorrer(func(tablero puzzle.Tablero, distancia int) {
+		h := heuristica(tablero)
+		switch {
+		case h > distancia:
+			v.Sobreestimados++
+		case h == distancia:
+			v.Exactos++
+		}
+		errorTotal += distancia - h
+		if distancia > 0 {
+			proporcionTotal += float64(h) / float64(distancia)
+		}
+	})
+	v.Admisible = v.Sobreestimados == 0
+	v.ErrorMedio = float64(errorTotal) / float64(tabla.Estados())
+	v.ProporcionMedia = proporcionTotal / float64(tabla.Estados()-1)
+	return v
+}
@@ -0,0 +1,17 @@
+/*
+Comando puzzle-cli: modo de línea de comandos del resolvedor, compilable sin Fyne.
+
+Ejecuta los mismos subcomandos que la aplicación gráfica (solve, shuffle, bench, check,
+table) en servidores sin pantalla. Ver el paquete cli para la lista completa de opciones.
+*/
+package main
+
+import (
+	"os"
+
+	"puzzle-solver/cli"
+)
+
+func main() {
+	os.Exit(cli.Ejecutar(os.Args[1:], os.Stdout, os.Stderr))
+}
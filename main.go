@@ -8,21 +8,41 @@ del 1 al 8 y un espacio vacío. El objetivo es ordenar las fichas desde cualquie
 inicial hasta el estado objetivo: 1,2,3,4,5,6,7,8,vacío.
 
 ALGORITMOS IMPLEMENTADOS:
-  - A* con Heurística Manhattan: Algoritmo de búsqueda informada que utiliza f(n) = g(n) + h(n)
-    donde g(n) es el costo del camino y h(n) es la distancia Manhattan al objetivo.
+  - A*: Algoritmo de búsqueda informada que utiliza f(n) = g(n) + h(n) donde g(n) es el
+    costo del camino y h(n) es la heurística seleccionada (Manhattan, Conflicto Lineal o una
+    base de patrones aditiva construida por BFS retrógrada y guardada en caché en disco).
+  - IDA*: Profundización iterativa sobre la cota de f(n); obtiene la
+    solución óptima de A* usando memoria proporcional a la profundidad de la solución.
   - Búsqueda en Anchura (BFS): Algoritmo de búsqueda no informada que explora nivel por nivel
     garantizando la solución óptima en número de movimientos.
 
 CARACTERÍSTICAS PRINCIPALES:
 - Interfaz gráfica moderna y profesional usando el framework Fyne
-- Visualización en tiempo real del estado del puzzle y heurística Manhattan
+- Tableros cuadrados (3x3, 4x4, 5x5) y rectangulares (2x3, 2x4, 3x4...) con filas y columnas independientes
+- Visualización en tiempo real del estado del puzzle y heurísticas Manhattan y Conflicto Lineal
+- Objetivo configurable: estándar, vacío al inicio, espiral o personalizado escrito por el usuario
 - Animaciones suaves para mostrar el movimiento de las piezas
-- Modo "Paso a Paso" para visualizar la solución completa
-- Métricas de rendimiento: tiempo de ejecución, número de pasos, eficiencia
-- Generación de configuraciones aleatorias garantizadas como solucionables
+- Modo "Paso a Paso" para visualizar la solución completa, con navegación hacia atrás y deslizador para saltar a cualquier paso
+- Reproducción automática de la solución con velocidad ajustable
+- Juego manual: clic en una ficha adyacente al vacío para deslizarla, con contador de movimientos y reloj
+- Control por teclado: flechas o WASD mueven el vacío y hay atajos para mezclar, resolver, avanzar, deshacer, reiniciar y cancelar
+- Historial para deshacer y rehacer movimientos, tanto manuales como de la solución paso a paso
+- Editor del tablero inicial: intercambio de casillas, teclas numéricas o un tablero pegado como texto, con veredicto de resolubilidad
+- Estadísticas de cada búsqueda: nodos, frontera y cerrada máximas, duplicados, factor de ramificación, memoria y tiempo
+- Generación de configuraciones aleatorias garantizadas como solucionables, con semilla visible y configurable para repetirlas
+- Dos modos de mezcla: caminata aleatoria desde el objetivo o uniforme entre todas las configuraciones resolubles
+- Mezcla a una profundidad exacta: tableros cuya solución óptima tiene justo los movimientos pedidos, con semilla reproducible
+- Verificación de resolubilidad por paridad de inversiones antes de cada búsqueda, con explicación
+- Búsqueda en segundo plano sin congelar la ventana, cancelable con el botón "Cancelar"
+- Progreso de la búsqueda en vivo: nodos expandidos, frontera, mejor f(n), profundidad y tiempo
 - Barra de progreso visual durante la ejecución de la solución
+- Tabla de distancias exactas de los 181.440 estados del 8-puzzle, guardada en caché en disco
+- Modo de línea de comandos sin interfaz gráfica: subcomandos solve, shuffle, bench, check y table
 
 ARQUITECTURA DEL SISTEMA:
+- puzzle (paquete): Núcleo de resolución independiente de la GUI, importable desde otras herramientas
+- cli (paquete): Subcomandos de línea de comandos; cmd/puzzle-cli los compila sin depender de Fyne
+- benchmark (paquete): Comparativas por lotes sobre suites de tableros con informes CSV y JSON
 - Estado: Representación de una configuración del puzzle con información de búsqueda
 - PuzzleButton: Widget personalizado con animación para cada celda del tablero
 - PuzzleApp: Controlador principal que gestiona la lógica de negocio y la interfaz
@@ -61,6 +81,8 @@ INSTRUCCIONES DE COMPILACIÓN:
 2. go get fyne.io/fyne/v2/app
 3. go build -o 8-puzzle-solver main.go
 4. ./8-puzzle-solver
+5. ./8-puzzle-solver solve -tablero "8 6 7 2 5 4 3 0 1" (modo línea de comandos)
+6. go build ./cmd/puzzle-cli (solo línea de comandos, sin dependencias gráficas)
 
 REFERENCIAS ACADÉMICAS:
 - Russell, S. & Norvig, P. "Artificial Intelligence: A Modern Approach"
@@ -69,16 +91,23 @@ REFERENCIAS ACADÉMICAS:
 package main
 
 import (
+	"context"
 	"fmt"
 	"image/color"
-	"math/rand"
+	"math/rand/v2"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"puzzle-solver/cli"
+	"puzzle-solver/puzzle"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
@@ -141,16 +170,6 @@ func (t MyTheme) Size(name fyne.ThemeSizeName) float32 {
 	}
 }
 
-// Estado representa un nodo en el árbol de búsqueda del problema del 8-puzzle.
-// Contiene la configuración actual del tablero, referencias para reconstruir el camino,
-// y metainformación para los algoritmos de búsqueda.
-type Estado struct {
-	tablero [9]int  // Configuración actual: posiciones 0-8, valor 0 representa espacio vacío
-	padre   *Estado // Referencia al estado padre para reconstruir la solución
-	costo   int     // g(n): Costo acumulado desde el estado inicial (profundidad)
-	accion  string  // Acción realizada para llegar a este estado desde el padre
-}
-
 // PuzzleButton es un widget personalizado que extiende widget.Button de Fyne
 // para representar cada celda del tablero del 8-puzzle con capacidades de animación.
 // Implementa feedback visual para mostrar qué pieza se está moviendo durante la solución.
@@ -176,8 +195,12 @@ func (pb *PuzzleButton) actualizarEstilo() {
 	// actualizarEstilo actualiza el aspecto visual del botón según su estado actual.
 	// Diferencia visualmente entre números, espacios vacíos y estados de animación.
 	if pb.esVacio {
-		pb.SetText("")                       // Completamente vacío, sin números
-		pb.Importance = widget.LowImportance // Estilo tenue para el espacio vacío
+		pb.SetText("") // Completamente vacío, sin números
+		if pb.destacado {
+			pb.Importance = widget.WarningImportance // Casilla vacía seleccionada en el editor
+		} else {
+			pb.Importance = widget.LowImportance // Estilo tenue para el espacio vacío
+		}
 	} else {
 		pb.SetText(strconv.Itoa(pb.numero))
 		if pb.destacado {
@@ -198,8 +221,9 @@ func (pb *PuzzleButton) setNumero(numero int) {
 	pb.actualizarEstilo()
 }
 
-func (pb *PuzzleButton) destacar() {
+func (pb *PuzzleButton) destacar(duracion time.Duration) {
 	// destacar resalta temporalmente el botón para indicar que esta pieza se va a mover.
+	// La duración sigue al deslizador de velocidad (800ms con la velocidad predeterminada).
 	if !pb.esVacio && pb.numero != 0 {
 		// Solo destacar si no es espacio vacío y contiene un número válido
 		pb.SetText(strconv.Itoa(pb.numero))
@@ -207,434 +231,994 @@ func (pb *PuzzleButton) destacar() {
 		pb.Importance = widget.WarningImportance // Verde claro brillante para animación
 		pb.Refresh()
 
-		// Quitar destaque después de 800ms usando una goroutine independiente
+		// Quitar destaque después de la duración indicada usando una goroutine independiente
 		go func() {
-			time.Sleep(800 * time.Millisecond)
+			time.Sleep(duracion)
 			fyne.DoAndWait(func() {
+				// La casilla pudo quedar vacía si la pieza se movió de nuevo durante el destaque
 				pb.destacado = false
-				if pb.numero != 0 {
-					pb.SetText(strconv.Itoa(pb.numero))
-				} else {
-					pb.SetText("") // Mantener vacío si es 0
-				}
-				pb.Importance = widget.HighImportance
-				pb.Refresh()
+				pb.actualizarEstilo()
 			})
 		}()
 	}
 }
 
+// Nombres de los algoritmos mostrados en el selector de búsqueda.
+const (
+	algoritmoAEstrella   = "A* (búsqueda informada)"
+	algoritmoIDAEstrella = "IDA* (profundización iterativa)"
+	algoritmoAnchura     = "Búsqueda en Anchura (BFS)"
+)
+
+// Nombres de las heurísticas mostradas en el selector de heurística (solo para A* e IDA*).
+const (
+	heuristicaManhattan       = "Distancia Manhattan"
+	heuristicaConflictoLineal = "Manhattan + Conflicto Lineal"
+	heuristicaPatrones        = "Base de Patrones Aditiva (PDB)"
+)
+
+// Nombres de los objetivos predefinidos mostrados en el editor de objetivo.
+const (
+	objetivoEstandar      = "Estándar (vacío al final)"
+	objetivoVacioInicial  = "Vacío al inicio"
+	objetivoEspiral       = "Espiral (caracol)"
+	objetivoPersonalizado = "Personalizado"
+)
+
+// Modos de mezcla mostrados en el selector junto a MEZCLAR.
+const (
+	mezclaCaminata = "Caminata aleatoria"
+	mezclaUniforme = "Uniforme entre resolubles"
+)
+
 // PuzzleApp es la estructura principal que gestiona toda la aplicación del 8-puzzle.
 // Implementa el patrón MVC (Modelo-Vista-Controlador) donde actúa como controlador,
 // gestionando la lógica de negocio, el estado del puzzle y la interfaz gráfica.
 type PuzzleApp struct {
-	window       fyne.Window         // Ventana principal de la aplicación
-	botones      [9]*PuzzleButton    // Array de botones representando el tablero 3x3
-	estadoActual [9]int              // Estado actual del puzzle (modelo de datos)
-	objetivo     [9]int              // Estado objetivo del puzzle [1,2,3,4,5,6,7,8,0]
-	solucion     []Estado            // Secuencia de estados que resuelven el puzzle
-	paso         int                 // Índice del paso actual en la visualización de la solución
-	infoLabel    *widget.RichText    // Panel de información con formato enriquecido
-	estadoLabel  *widget.Label       // Etiqueta de estado y heurística en tiempo real
-	algoritmo    *widget.Select      // Selector de algoritmo de búsqueda
-	progressBar  *widget.ProgressBar // Barra de progreso visual para la solución
+	window        fyne.Window         // Ventana principal de la aplicación
+	filas         int                 // Número de filas del tablero (2 a 5)
+	columnas      int                 // Número de columnas del tablero (2 a 5)
+	botones       []*PuzzleButton     // Botones representando el tablero, en orden de filas
+	cuadricula    *fyne.Container     // Contenedor de los botones, se reconstruye al cambiar el tamaño
+	estadoActual  puzzle.Tablero      // Estado actual del puzzle (modelo de datos)
+	objetivo      puzzle.Tablero      // Estado objetivo del puzzle, por ejemplo [1,2,3,4,5,6,7,8,0]
+	solucion      []puzzle.Estado     // Secuencia de estados que resuelven el puzzle
+	hSolucion     []int               // h(n) de cada estado de la solución, mostrado al recorrerla
+	paso          int                 // Índice del paso mostrado de la solución (0 es el tablero inicial)
+	infoLabel     *widget.RichText    // Panel de información con formato enriquecido
+	estadoLabel   *widget.Label       // Etiqueta de estado y heurística en tiempo real
+	selFilas      *widget.Select      // Selector del número de filas del tablero
+	selColumnas   *widget.Select      // Selector del número de columnas del tablero
+	algoritmo     *widget.Select      // Selector de algoritmo de búsqueda
+	heuristica    *widget.Select      // Selector de heurística para los algoritmos informados
+	tipoObjetivo  *widget.Select      // Selector del objetivo predefinido o personalizado
+	textoObjetivo *widget.Entry       // Objetivo escrito por el usuario, por ejemplo "1 2 3 8 0 4 7 6 5"
+	progressBar   *widget.ProgressBar // Barra de progreso visual para la solución
+	deslizador    *widget.Slider      // Deslizador para saltar a cualquier paso de la solución
+	velocidad     *widget.Slider      // Velocidad de la reproducción y las animaciones, en movimientos por segundo
+	btnReproducir *widget.Button      // Botón REPRODUCIR/PAUSA de la solución
+	pasoLabel     *widget.Label       // Paso mostrado, con su acción y h(n)
+	btnResolver   *widget.Button      // Botón RESOLVER, deshabilitado mientras hay una búsqueda en curso
+	btnCancelar   *widget.Button      // Botón CANCELAR, habilitado solo mientras hay una búsqueda en curso
+	cancelar      context.CancelFunc  // Cancela la búsqueda en segundo plano (nil si no hay ninguna)
+	partidaLabel  *widget.Label       // Contador de movimientos y reloj de la partida manual
+
+	sesion          *puzzle.Sesion // Historial de movimientos (manuales y paso a paso) para deshacer y rehacer
+	btnDeshacer     *widget.Button // Botón DESHACER, habilitado si hay movimientos en el historial
+	btnRehacer      *widget.Button // Botón REHACER, habilitado si hay movimientos deshechos
+	inicioPartida   time.Time      // Momento del primer movimiento manual (cero si el reloj está detenido)
+	duracionPartida time.Duration  // Tiempo final de la partida, fijado al resolverla
+	inicioContador  int            // Movimientos de la sesión anteriores a la partida en curso
+
+	modoEdicion      bool           // true mientras se edita el tablero inicial
+	borrador         puzzle.Tablero // Tablero en edición; se asigna a estadoActual al confirmar
+	seleccionEdicion int            // Casilla seleccionada en el editor (-1 si ninguna)
+	textoTablero     *widget.Entry  // Tablero como texto, editable o pegado, por ejemplo "1 2 3 4 0 6 7 5 8"
+	btnEditar        *widget.Button // Botón EDITAR TABLERO / CONFIRMAR TABLERO
+
+	detenerReproduccion context.CancelFunc // Detiene la reproducción automática (nil si no está en curso)
+
+	profundidad *widget.Entry  // Longitud óptima exacta pedida a MEZCLAR EXACTO, por ejemplo "20"
+	semilla     *widget.Entry  // Semilla de las mezclas; vacía para elegir una nueva en cada mezcla
+	modoMezcla  *widget.Select // Mezcla de MEZCLAR: caminata aleatoria o uniforme entre los resolubles
+
+	pdbs            map[puzzle.Tablero]*puzzle.BasePatrones // Bases de patrones por objetivo, cargadas de forma perezosa
+	construyendoPDB map[puzzle.Tablero]bool                 // Objetivos cuya base de patrones se está cargando o construyendo
 }
 
 func NuevaPuzzleApp() *PuzzleApp {
 	// NuevaPuzzleApp es el constructor que inicializa la estructura principal de la aplicación.
 	// Establece el estado objetivo estándar del 8-puzzle y valores iniciales.
 	return &PuzzleApp{
-		objetivo: [9]int{1, 2, 3, 4, 5, 6, 7, 8, 0}, // Configuración objetivo estándar
-		paso:     0,
+		filas:            3,
+		columnas:         3,
+		objetivo:         puzzle.TableroObjetivo(3, 3), // Configuración objetivo estándar
+		paso:             0,
+		seleccionEdicion: -1,
+		sesion:           puzzle.NuevaSesion(puzzle.TableroObjetivo(3, 3), puzzle.TableroObjetivo(3, 3)),
+		pdbs:             make(map[puzzle.Tablero]*puzzle.BasePatrones),
+		construyendoPDB:  make(map[puzzle.Tablero]bool),
 	}
 }
 
-func encontrarVacio(tablero [9]int) int {
-	// encontrarVacio localiza y retorna la posición del espacio vacío (representado por 0) en el tablero.
-	// Es una función auxiliar fundamental para generar movimientos válidos.
-	// Retorna: índice de la posición vacía (0-8), o -1 si no se encuentra.
-	for i := 0; i < 9; i++ {
-		if tablero[i] == 0 {
-			return i
-		}
+func nombreTamano(filas, columnas int) string {
+	// nombreTamano retorna el nombre del tablero mostrado al usuario, por ejemplo "4x4 (15-puzzle)".
+	return fmt.Sprintf("%dx%d (%d-puzzle)", filas, columnas, filas*columnas-1)
+}
+
+func (app *PuzzleApp) construirCuadricula() {
+	// construirCuadricula crea un botón por casilla del tablero actual y los distribuye
+	// en una cuadrícula de app.columnas columnas, reemplazando los botones anteriores.
+	app.botones = make([]*PuzzleButton, app.filas*app.columnas)
+	objetos := make([]fyne.CanvasObject, len(app.botones))
+	for i := range app.botones {
+		btn := NewPuzzleButton(app.objetivo.Valor(i))
+		btn.Resize(fyne.NewSize(100, 100))
+		btn.OnTapped = func() { app.moverFicha(i) } // Juego manual: deslizar la ficha hacia el vacío
+		app.botones[i] = btn
+		objetos[i] = btn
 	}
-	return -1 // No debería ocurrir en un puzzle válido
+	app.cuadricula.Layout = layout.NewGridLayoutWithColumns(app.columnas)
+	app.cuadricula.Objects = objetos
+	app.cuadricula.Refresh()
 }
 
-func esObjetivo(tablero [9]int, objetivo [9]int) bool {
-	// esObjetivo verifica si la configuración actual del tablero coincide con el estado objetivo.
-	// Es la condición de parada para los algoritmos de búsqueda.
-	// Retorna: true si el puzzle está resuelto, false en caso contrario.
-	for i := 0; i < 9; i++ {
-		if tablero[i] != objetivo[i] {
-			return false
-		}
+func (app *PuzzleApp) cambiarTamano(filas, columnas int) {
+	// cambiarTamano reconfigura la aplicación para un tablero de filas x columnas:
+	// deriva el nuevo objetivo, reconstruye la cuadrícula de botones y reinicia el puzzle.
+	if filas == app.filas && columnas == app.columnas && len(app.botones) == filas*columnas {
+		return
 	}
-	return true
+	app.filas, app.columnas = filas, columnas
+	if app.tipoObjetivo.Selected == objetivoPersonalizado {
+		// Un objetivo personalizado solo vale para su tamaño: volver al estándar
+		// (el cambio de selección vuelve a llamar a aplicarObjetivo)
+		app.tipoObjetivo.SetSelected(objetivoEstandar)
+		return
+	}
+	app.aplicarObjetivo()
 }
 
-func heuristicaManhattan(tablero [9]int) int {
-	// heuristicaManhattan calcula la función heurística h(n) para el algoritmo A*.
-	// La distancia Manhattan es la suma de distancias horizontales y verticales
-	// de cada ficha desde su posición actual hasta su posición objetivo.
-	// Esta heurística es admisible (nunca sobreestima) y consistente (monótona).
-	//
-	// Complejidad temporal: O(1) - siempre evalúa 9 posiciones
-	// Complejidad espacial: O(1) - usa memoria constante
-	//
-	// Retorna: suma total de distancias Manhattan de todas las fichas mal ubicadas
-	distancia := 0
-	for i := 0; i < 9; i++ {
-		if tablero[i] != 0 {
-			// Calcular posición actual en coordenadas (fila, columna)
-			fila_actual := i / 3
-			col_actual := i % 3
-
-			// Calcular posición objetivo en coordenadas (fila, columna)
-			valor := tablero[i]
-			fila_objetivo := (valor - 1) / 3 // valor-1 porque numeramos desde 1
-			col_objetivo := (valor - 1) % 3
-
-			// Sumar distancia Manhattan: |x1-x2| + |y1-y2|
-			distancia += abs(fila_actual-fila_objetivo) + abs(col_actual-col_objetivo)
-		}
+func (app *PuzzleApp) construirObjetivo() (puzzle.Tablero, error) {
+	// construirObjetivo genera el objetivo elegido en el editor para el tamaño actual.
+	// Los objetivos predefinidos se derivan de las dimensiones; el personalizado se lee del
+	// texto escrito por el usuario y se valida como permutación del tamaño correcto.
+	switch app.tipoObjetivo.Selected {
+	case objetivoVacioInicial:
+		return puzzle.ObjetivoVacioInicial(app.filas, app.columnas), nil
+	case objetivoEspiral:
+		return puzzle.ObjetivoEspiral(app.filas, app.columnas), nil
+	case objetivoPersonalizado:
+		return puzzle.ParsearTablero(app.filas, app.columnas, app.textoObjetivo.Text)
+	default:
+		return puzzle.TableroObjetivo(app.filas, app.columnas), nil
 	}
-	return distancia
 }
 
-func abs(x int) int {
-	// abs retorna el valor absoluto de un entero.
-	// Función auxiliar para cálculos de distancia.
-	if x < 0 {
-		return -x
+func (app *PuzzleApp) aplicarObjetivo() {
+	// aplicarObjetivo establece el objetivo elegido en el editor, reconstruye la cuadrícula si
+	// cambió el tamaño y reinicia el puzzle. Si el objetivo personalizado no es válido se
+	// informa el error y se conserva el objetivo anterior.
+	objetivo, err := app.construirObjetivo()
+	if err != nil {
+		app.infoLabel.ParseMarkdown(fmt.Sprintf("## OBJETIVO INVÁLIDO\n\n**Error:** %v\n\n**Acción:** Escribe las %d fichas (0 para el vacío) separadas por espacios", err, app.filas*app.columnas))
+		return
+	}
+	// Comparar filas y columnas, no solo el número de casillas: 2x3 y 3x2 tienen las mismas
+	anterior := app.objetivo
+	app.objetivo = objetivo
+	app.textoObjetivo.SetText(objetivo.String())
+	if len(app.botones) != app.filas*app.columnas || anterior.Filas() != app.filas || anterior.Columnas() != app.columnas {
+		app.construirCuadricula()
+	}
+	app.iniciar()
+
+	// La base de patrones depende del objetivo: prepararla si la heurística PDB está seleccionada
+	if app.heuristica.Selected == heuristicaPatrones {
+		app.prepararBasePatrones()
 	}
-	return x
 }
 
-func generarMovimientos(tablero [9]int) []Estado {
-	// generarMovimientos genera todos los movimientos válidos desde el estado actual del tablero.
-	// Un movimiento válido consiste en intercambiar el espacio vacío con una ficha adyacente
-	// (arriba, abajo, izquierda, derecha).
-	//
-	// Retorna: slice de Estados representando todos los sucesores posibles.
-	movimientos := []Estado{}
-	posVacio := encontrarVacio(tablero)
-
-	// Convertir posición lineal a coordenadas 2D
-	fila := posVacio / 3
-	col := posVacio % 3
-
-	// Generar movimiento hacia arriba (intercambiar con ficha de arriba)
-	if fila > 0 {
-		nuevo := tablero
-		nueva_pos := (fila-1)*3 + col
-		nuevo[posVacio], nuevo[nueva_pos] = nuevo[nueva_pos], nuevo[posVacio]
-		movimientos = append(movimientos, Estado{
-			tablero: nuevo,
-			accion:  "Arriba",
-		})
+func (app *PuzzleApp) heuristicaSeleccionada() puzzle.Heuristica {
+	// heuristicaSeleccionada traduce la opción del selector de heurística a la función del paquete puzzle,
+	// construida para el objetivo actual. La base de patrones debe estar cargada (ver prepararBasePatrones).
+	switch app.heuristica.Selected {
+	case heuristicaConflictoLineal:
+		return puzzle.NuevaHeuristicaConflictoLineal(app.objetivo)
+	case heuristicaPatrones:
+		return app.pdbs[app.objetivo].Evaluar
+	default:
+		return puzzle.NuevaHeuristicaManhattan(app.objetivo)
 	}
+}
 
-	// Generar movimiento hacia abajo (intercambiar con ficha de abajo)
-	if fila < 2 {
-		nuevo := tablero
-		nueva_pos := (fila+1)*3 + col
-		nuevo[posVacio], nuevo[nueva_pos] = nuevo[nueva_pos], nuevo[posVacio]
-		movimientos = append(movimientos, Estado{
-			tablero: nuevo,
-			accion:  "Abajo",
-		})
+func (app *PuzzleApp) prepararBasePatrones() {
+	// prepararBasePatrones carga la base de patrones desde la caché en disco o, si no existe,
+	// la construye en segundo plano mostrando el avance en la barra de progreso.
+	// Solo se ejecuta la primera vez que se selecciona la heurística PDB para cada objetivo.
+	filas, columnas := app.filas, app.columnas
+	objetivo := app.objetivo
+	if app.pdbs[objetivo] != nil || app.construyendoPDB[objetivo] {
+		return
 	}
+	app.construyendoPDB[objetivo] = true
+	app.progressBar.SetValue(0)
+	app.infoLabel.ParseMarkdown(fmt.Sprintf("## BASE DE PATRONES\n\n**Tablero:** %s\n\n**Estado:** Cargando o construyendo la base de patrones...\n\n**Por favor espera**", nombreTamano(filas, columnas)))
 
-	// Generar movimiento hacia izquierda (intercambiar con ficha de la izquierda)
-	if col > 0 {
-		nuevo := tablero
-		nueva_pos := fila*3 + (col - 1)
-		nuevo[posVacio], nuevo[nueva_pos] = nuevo[nueva_pos], nuevo[posVacio]
-		movimientos = append(movimientos, Estado{
-			tablero: nuevo,
-			accion:  "Izquierda",
+	grupos := puzzle.GruposPredeterminados(filas, columnas)
+	progreso := func(avance float64) {
+		fyne.Do(func() {
+			app.progressBar.SetValue(avance)
 		})
 	}
 
-	// Generar movimiento hacia derecha (intercambiar con ficha de la derecha)
-	if col < 2 {
-		nuevo := tablero
-		nueva_pos := fila*3 + (col + 1)
-		nuevo[posVacio], nuevo[nueva_pos] = nuevo[nueva_pos], nuevo[posVacio]
-		movimientos = append(movimientos, Estado{
-			tablero: nuevo,
-			accion:  "Derecha",
+	go func() {
+		base, err := puzzle.ObtenerBasePatrones(objetivo, grupos, progreso)
+
+		fyne.Do(func() {
+			app.pdbs[objetivo] = base
+			app.construyendoPDB[objetivo] = false
+			if err != nil {
+				app.infoLabel.ParseMarkdown(fmt.Sprintf("## BASE DE PATRONES LISTA\n\n**Advertencia:** %v\n\n**Acción:** Presiona 'Resolver' para usarla", err))
+			} else {
+				app.infoLabel.ParseMarkdown(fmt.Sprintf("## BASE DE PATRONES LISTA\n\n**Grupos:** %v\n\n**Acción:** Presiona 'Resolver' para usarla", grupos))
+			}
 		})
+	}()
+}
+
+func (app *PuzzleApp) actualizarTablero() {
+	// actualizarTablero sincroniza la interfaz gráfica con el estado actual del modelo de datos.
+	// Actualiza cada botón del tablero según los valores en estadoActual (o del borrador en el
+	// modo de edición) y, fuera de ese modo, el campo de texto del tablero.
+	tablero := app.tableroMostrado()
+	for i, btn := range app.botones {
+		btn.setNumero(tablero.Valor(i))
+	}
+	if app.modoEdicion && app.seleccionEdicion >= 0 {
+		app.botones[app.seleccionEdicion].destacado = true
+		app.botones[app.seleccionEdicion].actualizarEstilo()
+	}
+	if !app.modoEdicion {
+		app.textoTablero.SetText(app.estadoActual.String())
 	}
+	app.actualizarEstado()
+}
 
-	return movimientos
+func (app *PuzzleApp) tableroMostrado() puzzle.Tablero {
+	// tableroMostrado retorna el tablero visible: el borrador en el modo de edición o estadoActual.
+	if app.modoEdicion {
+		return app.borrador
+	}
+	return app.estadoActual
 }
 
-func busquedaAEstrella(inicial [9]int, objetivo [9]int) []Estado {
-	// busquedaAEstrella implementa el algoritmo A* (A-estrella) para encontrar la solución óptima.
-	//
-	// ALGORITMO A*:
-	// 1. Mantiene dos listas: ABIERTA (nodos por explorar) y CERRADA (nodos explorados)
-	// 2. Selecciona el nodo con menor f(n) = g(n) + h(n) de la lista ABIERTA
-	// 3. Si es el objetivo, reconstruye y retorna el camino
-	// 4. Si no, expande sus sucesores y los agrega a ABIERTA si no están en CERRADA
-	// 5. Repite hasta encontrar solución o agotar posibilidades
-	//
-	// PROPIEDADES:
-	// - Completitud: Siempre encuentra solución si existe
-	// - Optimalidad: Garantiza la solución de menor costo con heurística admisible
-	// - Complejidad temporal: O(b^d) donde b=factor ramificación, d=profundidad solución
-	// - Complejidad espacial: O(b^d) para almacenar nodos en memoria
-	//
-	// PARÁMETROS:
-	// - inicial: configuración inicial del tablero [9]int
-	// - objetivo: configuración objetivo del tablero [9]int
-	//
-	// RETORNA: slice de Estados representando el camino solución (vacío si no hay solución)
-
-	// Inicializar lista ABIERTA con el estado inicial
-	abierta := []Estado{{tablero: inicial, costo: 0}}
-	// Lista CERRADA para evitar reexplorar estados
-	cerrada := []string{}
-
-	for len(abierta) > 0 {
-		// Encontrar el nodo con menor f(n) = g(n) + h(n) en la lista ABIERTA
-		indice_mejor := 0
-		mejor_f := abierta[0].costo + heuristicaManhattan(abierta[0].tablero)
-
-		for i := 1; i < len(abierta); i++ {
-			f := abierta[i].costo + heuristicaManhattan(abierta[i].tablero)
-			if f < mejor_f {
-				mejor_f = f
-				indice_mejor = i
-			}
-		}
+func (app *PuzzleApp) actualizarEstado() {
+	// actualizarEstado actualiza la información de estado mostrada al usuario.
+	// Muestra si el puzzle está resuelto o en proceso, junto con los valores de ambas heurísticas.
+	// En el modo de edición describe el borrador, de modo que el veredicto se actualiza en vivo.
+	tablero := app.tableroMostrado()
+	if puzzle.EsObjetivo(tablero, app.objetivo) {
+		app.estadoLabel.SetText("ESTADO: RESUELTO")
+		app.estadoLabel.Importance = widget.SuccessImportance
+	} else if analisis := puzzle.AnalizarResolubilidad(tablero, app.objetivo); !analisis.Resoluble {
+		app.estadoLabel.SetText(fmt.Sprintf("ESTADO: SIN SOLUCIÓN | Inversiones: %d | Paridad: %d", analisis.Inversiones, analisis.Paridad))
+		app.estadoLabel.Importance = widget.DangerImportance
+	} else {
+		manhattan := puzzle.NuevaHeuristicaManhattan(app.objetivo)(tablero)
+		conflicto := puzzle.NuevaHeuristicaConflictoLineal(app.objetivo)(tablero)
+		app.estadoLabel.SetText(fmt.Sprintf("ESTADO: EN PROCESO | Manhattan: %d | Conflicto Lineal: %d", manhattan, conflicto))
+		app.estadoLabel.Importance = widget.MediumImportance
+	}
+}
 
-		// Extraer el mejor nodo de la lista ABIERTA
-		actual := abierta[indice_mejor]
-		abierta = append(abierta[:indice_mejor], abierta[indice_mejor+1:]...)
-
-		// Verificar si alcanzamos el estado objetivo
-		if esObjetivo(actual.tablero, objetivo) {
-			// Reconstruir el camino desde el objetivo hasta el inicio
-			camino := []Estado{}
-			estado := &actual
-			for estado != nil {
-				camino = append([]Estado{*estado}, camino...)
-				estado = estado.padre
-			}
-			return camino
-		}
+func (app *PuzzleApp) moverFicha(indice int) {
+	// moverFicha atiende el clic sobre una casilla del tablero: si la ficha es adyacente al
+	// espacio vacío la desliza hacia él, destaca la pieza movida y avanza el contador de la
+	// partida. El reloj arranca con el primer movimiento y se detiene al alcanzar el objetivo.
+	// Los clics se ignoran mientras hay una búsqueda en curso, ya que cambiarían su tablero.
+	if app.modoEdicion {
+		app.seleccionarCasilla(indice)
+		return
+	}
+	if app.cancelar != nil {
+		return
+	}
+	if app.inicioPartida.IsZero() && app.duracionPartida > 0 {
+		// Nueva partida desde el tablero actual tras resolver la anterior: solo se reinician el
+		// contador y el reloj, el historial se conserva para poder repasar la partida terminada
+		app.inicioContador = app.sesion.Movimientos()
+		app.duracionPartida = 0
+	}
+	vacio := puzzle.EncontrarVacio(app.estadoActual)
+	if !app.sesion.MoverFicha(indice) {
+		return // La ficha no es adyacente al vacío
+	}
 
-		// Agregar el estado actual a la lista CERRADA
-		cerrada = append(cerrada, fmt.Sprintf("%v", actual.tablero))
+	// El movimiento manual invalida la solución cargada para el tablero anterior
+	app.limpiarSolucion()
 
-		// Generar y evaluar todos los sucesores del estado actual
-		for _, movimiento := range generarMovimientos(actual.tablero) {
-			estado_str := fmt.Sprintf("%v", movimiento.tablero)
+	if app.inicioPartida.IsZero() {
+		app.inicioPartida = time.Now()
+	}
+	app.estadoActual = app.sesion.Tablero()
+	app.actualizarTablero()
+	app.botones[vacio].destacar(app.retardo()) // La ficha ocupa ahora la casilla que estaba vacía
 
-			// Verificar si el sucesor ya fue explorado (está en CERRADA)
-			ya_explorado := false
-			for _, cerrado := range cerrada {
-				if cerrado == estado_str {
-					ya_explorado = true
-					break
-				}
-			}
+	if app.sesion.Resuelta() {
+		app.duracionPartida = time.Since(app.inicioPartida)
+		app.inicioPartida = time.Time{}
+		app.infoLabel.ParseMarkdown(fmt.Sprintf("## ¡RESUELTO!\n\n**Movimientos:** %d\n\n**Tiempo:** %s\n\n**Acción:** Presiona 'Mezclar' para jugar otra partida",
+			app.movimientosPartida(), formatearReloj(app.duracionPartida)))
+	}
+	app.actualizarPartida()
+}
 
-			// Si no está explorado, agregarlo a la lista ABIERTA
-			if !ya_explorado {
-				movimiento.padre = &actual
-				movimiento.costo = actual.costo + 1
-				abierta = append(abierta, movimiento)
-			}
+func (app *PuzzleApp) moverVacio(accion string) {
+	// moverVacio desplaza el espacio vacío en la dirección indicada ("Arriba", "Abajo",
+	// "Izquierda" o "Derecha"), equivalente a hacer clic en la ficha vecina en esa dirección.
+	// Si el vacío está en el borde correspondiente no hace nada.
+	for _, sucesor := range puzzle.GenerarMovimientos(app.estadoActual) {
+		if sucesor.Accion == accion {
+			app.moverFicha(puzzle.EncontrarVacio(sucesor.Tablero))
+			return
 		}
 	}
+}
 
-	return []Estado{} // Retornar lista vacía si no hay solución
+func (app *PuzzleApp) deshacer() {
+	// deshacer revierte el último movimiento del historial, hecho a mano o con "Paso a Paso".
+	if app.cancelar != nil || app.modoEdicion {
+		return
+	}
+	app.pausar()
+	if _, ok := app.sesion.Deshacer(); ok {
+		app.restaurarSesion()
+	}
 }
 
-func busquedaAnchura(inicial [9]int, objetivo [9]int) []Estado {
-	// busquedaAnchura implementa el algoritmo de Búsqueda en Anchura (BFS) para resolver el puzzle.
-	//
-	// ALGORITMO BFS:
-	// 1. Utiliza una cola FIFO (First In, First Out) para explorar nodos nivel por nivel
-	// 2. Explora todos los nodos a profundidad d antes de explorar nodos a profundidad d+1
-	// 3. Mantiene lista de visitados para evitar ciclos infinitos
-	// 4. Garantiza encontrar la solución con menor número de movimientos
-	//
-	// PROPIEDADES:
-	// - Completitud: Siempre encuentra solución si existe y el espacio es finito
-	// - Optimalidad: Garantiza solución óptima en número de movimientos (costo uniforme)
-	// - Complejidad temporal: O(b^d) donde b=factor ramificación, d=profundidad solución
-	// - Complejidad espacial: O(b^d) para almacenar todos los nodos de cada nivel
-	//
-	// DIFERENCIAS CON A*:
-	// - No usa heurística (búsqueda ciega)
-	// - Explora más nodos que A* en promedio
-	// - Útil cuando no se dispone de heurística admisible
-	// - Mejor para problemas donde todos los movimientos tienen el mismo costo
-	//
-	// PARÁMETROS:
-	// - inicial: configuración inicial del tablero [9]int
-	// - objetivo: configuración objetivo del tablero [9]int
+func (app *PuzzleApp) rehacer() {
+	// rehacer vuelve a aplicar el último movimiento deshecho.
+	if app.cancelar != nil || app.modoEdicion {
+		return
+	}
+	app.pausar()
+	if _, ok := app.sesion.Rehacer(); ok {
+		app.restaurarSesion()
+	}
+}
+
+func (app *PuzzleApp) restaurarSesion() {
+	// restaurarSesion muestra el tablero vigente de la sesión tras deshacer o rehacer. Si el
+	// tablero es uno de los pasos de la solución cargada, la visualización se ubica en él; si se
+	// salió del camino de la solución, esta se descarta.
+	app.estadoActual = app.sesion.Tablero()
+	app.actualizarTablero()
+	app.actualizarPartida()
+	for i, estado := range app.solucion {
+		if estado.Tablero == app.estadoActual {
+			app.paso = i
+			app.mostrarPaso()
+			return
+		}
+	}
+	app.limpiarSolucion()
+}
+
+// teclasVacio asocia las flechas y WASD con la dirección en que se desplaza el espacio vacío.
+var teclasVacio = map[fyne.KeyName]string{
+	fyne.KeyUp: "Arriba", fyne.KeyDown: "Abajo", fyne.KeyLeft: "Izquierda", fyne.KeyRight: "Derecha",
+	fyne.KeyW: "Arriba", fyne.KeyS: "Abajo", fyne.KeyA: "Izquierda", fyne.KeyD: "Derecha",
+}
+
+func (app *PuzzleApp) teclaPulsada(evento *fyne.KeyEvent) {
+	// teclaPulsada atiende el teclado cuando ningún control tiene el foco, de modo que escribir
+	// en el campo del objetivo no mueve fichas. Las teclas de avance de página permiten usar
+	// un presentador inalámbrico (clicker) para recorrer la solución.
 	//
-	// RETORNA: slice de Estados representando el camino solución (vacío si no hay solución)
-
-	// Inicializar cola FIFO con el estado inicial
-	cola := []Estado{{tablero: inicial}}
-	// Lista de estados visitados para evitar ciclos
-	visitados := []string{}
-
-	for len(cola) > 0 {
-		// Extraer el primer elemento de la cola (FIFO)
-		actual := cola[0]
-		cola = cola[1:]
-
-		// Verificar si alcanzamos el estado objetivo
-		if esObjetivo(actual.tablero, objetivo) {
-			// Reconstruir el camino desde el objetivo hasta el inicio
-			camino := []Estado{}
-			estado := &actual
-			for estado != nil {
-				camino = append([]Estado{*estado}, camino...)
-				estado = estado.padre
-			}
-			return camino
+	// TECLAS:
+	// - Flechas o WASD: mover el espacio vacío
+	// - M: mezclar | Enter: resolver | Espacio, P o Av Pág: paso a paso | Re Pág: paso anterior
+	// - Inicio y Fin: primer y último paso de la solución | R: reproducir o pausar la solución
+	// - I: iniciar (reiniciar al objetivo) | U o Retroceso: deshacer | Y: rehacer | Esc: cancelar la búsqueda
+	if app.modoEdicion {
+		app.teclaEdicion(evento)
+		return
+	}
+	if accion, existe := teclasVacio[evento.Name]; existe {
+		app.moverVacio(accion)
+		return
+	}
+	switch evento.Name {
+	case fyne.KeyM:
+		app.mezclar()
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if !app.btnResolver.Disabled() {
+			app.resolver()
 		}
+	case fyne.KeySpace, fyne.KeyP, fyne.KeyPageDown:
+		app.siguientePaso()
+	case fyne.KeyR:
+		app.reproducir()
+	case fyne.KeyPageUp:
+		app.pasoAnterior()
+	case fyne.KeyHome:
+		app.primerPaso()
+	case fyne.KeyEnd:
+		app.ultimoPaso()
+	case fyne.KeyI:
+		app.iniciar()
+	case fyne.KeyU, fyne.KeyBackspace:
+		app.deshacer()
+	case fyne.KeyY:
+		app.rehacer()
+	case fyne.KeyEscape:
+		app.cancelarBusqueda()
+	}
+}
 
-		// Marcar el estado actual como visitado
-		estado_str := fmt.Sprintf("%v", actual.tablero)
-		visitados = append(visitados, estado_str)
+func (app *PuzzleApp) registrarTeclado() {
+	// registrarTeclado conecta el teclado con la ventana: las teclas simples se reciben en el
+	// lienzo y los atajos estándar de deshacer y rehacer (Ctrl+Z y Ctrl+Y, o Cmd en macOS) se
+	// registran aparte.
+	lienzo := app.window.Canvas()
+	lienzo.SetOnTypedKey(app.teclaPulsada)
+	lienzo.AddShortcut(&fyne.ShortcutUndo{}, func(fyne.Shortcut) { app.deshacer() })
+	lienzo.AddShortcut(&fyne.ShortcutRedo{}, func(fyne.Shortcut) { app.rehacer() })
+}
 
-		// Generar y evaluar todos los sucesores del estado actual
-		for _, movimiento := range generarMovimientos(actual.tablero) {
-			mov_str := fmt.Sprintf("%v", movimiento.tablero)
+func (app *PuzzleApp) reiniciarPartida() {
+	// reiniciarPartida inicia una sesión nueva en el tablero actual: vacía el historial y pone
+	// a cero el contador y el reloj de la partida manual. Se invoca cada vez que el tablero se
+	// reemplaza (iniciar, mezclar, cambiar el objetivo).
+	app.sesion = puzzle.NuevaSesion(app.estadoActual, app.objetivo)
+	app.inicioPartida = time.Time{}
+	app.duracionPartida = 0
+	app.inicioContador = 0
+	app.actualizarPartida()
+}
 
-			// Verificar si el sucesor ya fue visitado
-			ya_visitado := false
-			for _, v := range visitados {
-				if v == mov_str {
-					ya_visitado = true
-					break
-				}
-			}
+func (app *PuzzleApp) movimientosPartida() int {
+	// movimientosPartida retorna los movimientos de la partida en curso, sin contar los de las
+	// partidas anteriores de la misma sesión. Deshacer por debajo del inicio de la partida la
+	// extiende hacia atrás, de modo que el contador nunca es negativo.
+	app.inicioContador = min(app.inicioContador, app.sesion.Movimientos())
+	return app.sesion.Movimientos() - app.inicioContador
+}
 
-			// Si no está visitado, agregarlo a la cola
-			if !ya_visitado {
-				movimiento.padre = &actual
-				cola = append(cola, movimiento)
-			}
+func (app *PuzzleApp) actualizarPartida() {
+	// actualizarPartida muestra el contador de movimientos y el reloj de la partida manual, y
+	// habilita DESHACER y REHACER según el historial. Se invoca tras cada movimiento y una vez
+	// por segundo mientras el reloj está en marcha.
+	duracion := app.duracionPartida
+	if !app.inicioPartida.IsZero() {
+		duracion = time.Since(app.inicioPartida)
+	}
+	app.partidaLabel.SetText(fmt.Sprintf("Movimientos: %d | Tiempo: %s", app.movimientosPartida(), formatearReloj(duracion)))
+
+	if app.sesion.PuedeDeshacer() {
+		app.btnDeshacer.Enable()
+	} else {
+		app.btnDeshacer.Disable()
+	}
+	if app.sesion.PuedeRehacer() {
+		app.btnRehacer.Enable()
+	} else {
+		app.btnRehacer.Disable()
+	}
+}
+
+func formatearReloj(duracion time.Duration) string {
+	// formatearReloj expresa una duración como minutos y segundos, por ejemplo "02:05".
+	segundos := int(duracion.Seconds())
+	return fmt.Sprintf("%02d:%02d", segundos/60, segundos%60)
+}
+
+func (app *PuzzleApp) alternarEdicion() {
+	// alternarEdicion atiende el botón EDITAR TABLERO / CONFIRMAR TABLERO.
+	if app.modoEdicion {
+		app.confirmarEdicion()
+	} else {
+		app.iniciarEdicion()
+	}
+}
+
+func (app *PuzzleApp) iniciarEdicion() bool {
+	// iniciarEdicion entra al modo de edición con un borrador igual al tablero actual. Mientras
+	// dura, los clics intercambian casillas, las teclas numéricas colocan fichas y el campo de
+	// texto acepta un tablero pegado. La solución cargada se descarta porque dejará de valer.
+	// No se permite editar durante una búsqueda.
+	//
+	// RETORNA: true si el modo de edición quedó activo
+	if app.cancelar != nil {
+		return false
+	}
+	if !app.modoEdicion {
+		app.limpiarSolucion()
+		app.modoEdicion = true
+		app.borrador = app.estadoActual
+		app.seleccionEdicion = -1
+		app.btnEditar.SetText("CONFIRMAR TABLERO")
+		app.btnEditar.Importance = widget.SuccessImportance
+		app.btnEditar.Refresh()
+		app.mostrarEdicion(nil)
+	}
+	return true
+}
+
+func (app *PuzzleApp) seleccionarCasilla(indice int) {
+	// seleccionarCasilla atiende los clics en el modo de edición: el primer clic selecciona una
+	// casilla y el segundo la intercambia con la seleccionada (un clic sobre la misma la libera).
+	switch {
+	case app.seleccionEdicion < 0:
+		app.seleccionEdicion = indice
+	case app.seleccionEdicion == indice:
+		app.seleccionEdicion = -1
+	default:
+		app.borrador = app.borrador.Intercambiar(app.seleccionEdicion, indice)
+		app.seleccionEdicion = -1
+	}
+	app.mostrarEdicion(nil)
+}
+
+func (app *PuzzleApp) teclaEdicion(evento *fyne.KeyEvent) {
+	// teclaEdicion atiende el teclado en el modo de edición: un dígito coloca esa ficha en la
+	// casilla seleccionada (intercambiándola con la casilla donde estaba), Enter confirma el
+	// borrador y Esc lo descarta. Las fichas de dos cifras se escriben en el campo de texto.
+	switch evento.Name {
+	case fyne.KeyReturn, fyne.KeyEnter:
+		app.confirmarEdicion()
+		return
+	case fyne.KeyEscape:
+		app.descartarEdicion()
+		app.actualizarTablero()
+		app.infoLabel.ParseMarkdown("## EDICIÓN DESCARTADA\n\n**Estado:** Se conserva el tablero anterior")
+		return
+	}
+	valor, err := strconv.Atoi(string(evento.Name))
+	if err != nil || app.seleccionEdicion < 0 || valor >= app.borrador.Tamano() {
+		return
+	}
+	for i := 0; i < app.borrador.Tamano(); i++ {
+		if app.borrador.Valor(i) == valor {
+			app.borrador = app.borrador.Intercambiar(app.seleccionEdicion, i)
+			break
 		}
 	}
+	app.seleccionEdicion = -1
+	app.mostrarEdicion(nil)
+}
 
-	return []Estado{} // Retornar lista vacía si no hay solución
+func (app *PuzzleApp) editarTexto(texto string) {
+	// editarTexto atiende los cambios del campo de texto del tablero: si el texto es una
+	// permutación válida del tamaño actual reemplaza el borrador; si no, muestra el error sin
+	// modificarlo. Escribir en el campo entra al modo de edición automáticamente.
+	if !app.modoEdicion {
+		if texto == app.estadoActual.String() || !app.iniciarEdicion() {
+			return // Actualización del propio campo o búsqueda en curso
+		}
+	}
+	tablero, err := puzzle.ParsearTablero(app.filas, app.columnas, texto)
+	if err != nil {
+		app.mostrarEdicion(err)
+		return
+	}
+	if tablero != app.borrador {
+		app.borrador = tablero
+		app.seleccionEdicion = -1
+		app.mostrarEdicion(nil)
+	}
 }
 
-func (app *PuzzleApp) actualizarTablero() {
-	// actualizarTablero sincroniza la interfaz gráfica con el estado actual del modelo de datos.
-	// Actualiza cada botón del tablero según los valores en estadoActual.
-	for i := 0; i < 9; i++ {
-		app.botones[i].setNumero(app.estadoActual[i])
+func (app *PuzzleApp) mostrarEdicion(err error) {
+	// mostrarEdicion muestra el borrador en el tablero y en el campo de texto junto con el
+	// veredicto de resolubilidad respecto al objetivo, o el error del texto escrito.
+	if err != nil {
+		app.infoLabel.ParseMarkdown(fmt.Sprintf("## TABLERO INVÁLIDO\n\n**Error:** %v\n\n**Acción:** Escribe las %d fichas (0 para el vacío) separadas por espacios, sin repetir ninguna",
+			err, app.filas*app.columnas))
+		return
 	}
-	app.actualizarEstado()
+	app.actualizarTablero()
+	if app.textoTablero.Text != app.borrador.String() {
+		app.textoTablero.SetText(app.borrador.String()) // Reingresa a editarTexto sin efecto
+	}
+
+	analisis := puzzle.AnalizarResolubilidad(app.borrador, app.objetivo)
+	veredicto := "Sí"
+	if !analisis.Resoluble {
+		veredicto = "No"
+	}
+	app.infoLabel.ParseMarkdown(fmt.Sprintf("## EDITANDO TABLERO\n\n**Tablero:** %s\n\n**Resoluble:** %s\n\n**Inversiones:** %d\n\n**Explicación:** %s\n\n"+
+		"**Acción:** Haz clic en dos casillas para intercambiarlas, selecciona una y escribe un número para colocar esa ficha, o pega un tablero en el campo de texto. "+
+		"'Confirmar tablero' (o Enter) lo aplica y Esc lo descarta",
+		app.borrador, veredicto, analisis.Inversiones, analisis.Explicacion))
 }
 
-func (app *PuzzleApp) actualizarEstado() {
-	// actualizarEstado actualiza la información de estado mostrada al usuario.
-	// Muestra si el puzzle está resuelto o en proceso, junto con la heurística Manhattan actual.
-	if esObjetivo(app.estadoActual, app.objetivo) {
-		app.estadoLabel.SetText("ESTADO: RESUELTO")
-		app.estadoLabel.Importance = widget.SuccessImportance
-	} else {
-		manhattan := heuristicaManhattan(app.estadoActual)
-		app.estadoLabel.SetText(fmt.Sprintf("ESTADO: EN PROCESO | Heurística Manhattan: %d", manhattan))
-		app.estadoLabel.Importance = widget.MediumImportance
+func (app *PuzzleApp) confirmarEdicion() {
+	// confirmarEdicion sale del modo de edición y asigna el borrador a estadoActual, iniciando
+	// una partida nueva. Si el borrador no tiene solución lo explica; el tablero se conserva de
+	// todos modos para poder estudiarlo.
+	if !app.modoEdicion {
+		return
+	}
+	tablero := app.borrador
+	app.descartarEdicion()
+	app.estadoActual = tablero
+	app.limpiarSolucion()
+	app.actualizarTablero()
+	app.reiniciarPartida()
+
+	if analisis := puzzle.AnalizarResolubilidad(app.estadoActual, app.objetivo); !analisis.Resoluble {
+		app.mostrarIrresoluble(analisis)
+		return
 	}
+	manhattan := puzzle.NuevaHeuristicaManhattan(app.objetivo)(app.estadoActual)
+	conflicto := puzzle.NuevaHeuristicaConflictoLineal(app.objetivo)(app.estadoActual)
+	app.infoLabel.ParseMarkdown(fmt.Sprintf("## TABLERO EDITADO\n\n**Tablero:** %s\n\n**Heurística Manhattan:** %d\n\n**Manhattan + Conflicto Lineal:** %d\n\n**Acción:** Selecciona algoritmo y presiona 'Resolver'",
+		app.estadoActual, manhattan, conflicto))
+}
+
+func (app *PuzzleApp) descartarEdicion() {
+	// descartarEdicion sale del modo de edición sin modificar estadoActual.
+	if !app.modoEdicion {
+		return
+	}
+	app.modoEdicion = false
+	app.seleccionEdicion = -1
+	app.btnEditar.SetText("EDITAR TABLERO")
+	app.btnEditar.Importance = widget.MediumImportance
+	app.btnEditar.Refresh()
 }
 
 func (app *PuzzleApp) iniciar() {
 	// iniciar reinicia el puzzle al estado objetivo ordenado y limpia todas las variables de control.
 	// Utilizado para comenzar una nueva sesión o reiniciar después de completar una solución.
-	app.estadoActual = [9]int{1, 2, 3, 4, 5, 6, 7, 8, 0}
-	app.solucion = []Estado{}
-	app.paso = 0
-	app.progressBar.SetValue(0)
+	app.detenerBusqueda()
+	app.descartarEdicion()
+	app.estadoActual = app.objetivo
+	app.limpiarSolucion()
 	app.actualizarTablero()
+	app.reiniciarPartida()
 
-	app.infoLabel.ParseMarkdown("## SISTEMA INICIALIZADO\n\n**Estado:** Puzzle ordenado correctamente\n\n**Acción:** Presiona 'Mezclar' para comenzar")
+	app.infoLabel.ParseMarkdown("## SISTEMA INICIALIZADO\n\n**Estado:** Puzzle ordenado correctamente\n\n**Acción:** Presiona 'Mezclar' para comenzar\n\n" +
+		"**Teclado:** flechas o WASD mueven el vacío, M mezcla, Enter resuelve, Espacio o Av Pág avanza un paso, Re Pág retrocede, Inicio y Fin saltan al primer y último paso, R reproduce o pausa, U deshace, Y rehace, I reinicia y Esc cancela")
 }
 
 func (app *PuzzleApp) mezclar() {
-	// mezclar genera una configuración aleatoria del puzzle realizando movimientos válidos.
-	// Garantiza que la configuración resultante sea solucionable al partir del estado objetivo
-	// y aplicar movimientos válidos únicamente.
-	rand.Seed(time.Now().UnixNano())
+	// mezclar genera una configuración aleatoria del puzzle según el modo seleccionado:
+	// - Caminata aleatoria: 150 movimientos válidos desde el objetivo, sesgada hacia tableros cercanos
+	// - Uniforme: cualquier configuración resoluble con la misma probabilidad (permutación al azar
+	//   corregida por paridad), útil para comparaciones estadísticas
+	// Ambos modos garantizan un tablero resoluble. Usa la semilla del campo Semilla, o una nueva
+	// si está vacío, y la muestra para poder repetir la mezcla.
+	semilla, ok := app.semillaMezcla()
+	if !ok {
+		return
+	}
+	app.detenerBusqueda()
+	app.descartarEdicion()
 
 	app.infoLabel.ParseMarkdown("## MEZCLANDO PUZZLE\n\n**Estado:** Generando configuración aleatoria...\n\n**Por favor espera**")
 
-	// Partir del estado objetivo y aplicar movimientos aleatorios válidos
-	app.estadoActual = app.objetivo
-	for i := 0; i < 150; i++ {
-		movimientos := generarMovimientos(app.estadoActual)
-		if len(movimientos) > 0 {
-			// Seleccionar un movimiento aleatorio de los disponibles
-			mov := movimientos[rand.Intn(len(movimientos))]
-			app.estadoActual = mov.tablero
-		}
+	rng := puzzle.NuevoGenerador(semilla)
+	if app.modoMezcla.Selected == mezclaUniforme {
+		app.estadoActual = puzzle.MezclarUniforme(app.objetivo, rng)
+	} else {
+		// Partir del estado objetivo y aplicar movimientos aleatorios válidos
+		app.estadoActual = puzzle.MezclarAleatorio(app.objetivo, 150, rng)
 	}
 
-	// Limpiar variables de control para nueva búsqueda
-	app.solucion = []Estado{}
-	app.paso = 0
-	app.progressBar.SetValue(0)
+	// Limpiar variables de control para nueva búsqueda y nueva partida manual
+	app.limpiarSolucion()
 	app.actualizarTablero()
+	app.reiniciarPartida()
 
-	manhattan := heuristicaManhattan(app.estadoActual)
-	app.infoLabel.ParseMarkdown(fmt.Sprintf("## PUZZLE MEZCLADO\n\n**Estado:** Configuración aleatoria generada\n\n**Heurística Manhattan:** %d\n\n**Acción:** Selecciona algoritmo y presiona 'Resolver'", manhattan))
+	manhattan := puzzle.NuevaHeuristicaManhattan(app.objetivo)(app.estadoActual)
+	conflicto := puzzle.NuevaHeuristicaConflictoLineal(app.objetivo)(app.estadoActual)
+	app.infoLabel.ParseMarkdown(fmt.Sprintf("## PUZZLE MEZCLADO\n\n**Estado:** Configuración aleatoria generada\n\n**Modo:** %s\n\n**Semilla:** %d\n\n**Heurística Manhattan:** %d\n\n**Manhattan + Conflicto Lineal:** %d\n\n**Acción:** Selecciona algoritmo y presiona 'Resolver', o haz clic en las fichas junto al vacío para jugar", app.modoMezcla.Selected, semilla, manhattan, conflicto))
+}
+
+func (app *PuzzleApp) semillaMezcla() (uint64, bool) {
+	// semillaMezcla retorna la semilla de la próxima mezcla: la escrita en el campo Semilla,
+	// que repite siempre el mismo tablero, o una tomada del reloj si el campo está vacío.
+	//
+	// RETORNA: false si el campo no contiene un número válido (ya se avisó en el panel)
+	texto := strings.TrimSpace(app.semilla.Text)
+	if texto == "" {
+		return uint64(time.Now().UnixNano()), true
+	}
+	semilla, err := strconv.ParseUint(texto, 10, 64)
+	if err != nil {
+		app.infoLabel.ParseMarkdown("## ADVERTENCIA\n\n**Estado:** Semilla inválida\n\n**Acción:** Escribe un número entero no negativo, o deja el campo vacío para una semilla aleatoria")
+		return 0, false
+	}
+	return semilla, true
+}
+
+func (app *PuzzleApp) mezclarExacto() {
+	// mezclarExacto genera un tablero cuya solución óptima tiene exactamente los movimientos
+	// indicados en el campo de profundidad. Hasta 3x3 lo elige uniformemente con la tabla de
+	// distancias completa; en tableros mayores verifica cada candidato con IDA*. La generación
+	// corre en segundo plano y puede cancelarse como una búsqueda. Usa la misma semilla que
+	// mezclar y la muestra al terminar para poder repetir el mismo tablero.
+	k, err := strconv.Atoi(strings.TrimSpace(app.profundidad.Text))
+	if err != nil || k < 0 {
+		app.infoLabel.ParseMarkdown("## ADVERTENCIA\n\n**Estado:** Profundidad inválida\n\n**Acción:** Escribe la longitud de la solución óptima deseada, por ejemplo 20")
+		return
+	}
+	semilla, ok := app.semillaMezcla()
+	if !ok {
+		return
+	}
+	app.detenerBusqueda()
+	app.descartarEdicion()
+	app.limpiarSolucion()
+
+	ctx, cancelar := context.WithCancel(context.Background())
+	app.cancelar = cancelar
+	app.btnResolver.Disable()
+	app.btnCancelar.Enable()
+	app.infoLabel.ParseMarkdown(fmt.Sprintf("## MEZCLANDO PUZZLE\n\n**Estado:** Buscando un tablero a exactamente %d movimientos del objetivo...\n\n**Acción:** Presiona 'Cancelar' para detener la generación", k))
+
+	objetivo := app.objetivo
+	go func() {
+		tablero, err := mezclarADistancia(ctx, objetivo, k, puzzle.NuevoGenerador(semilla))
+		fyne.Do(func() {
+			if ctx.Err() != nil {
+				// Generación cancelada: quien la canceló ya actualizó la interfaz
+				return
+			}
+			app.detenerBusqueda()
+			if err != nil {
+				app.infoLabel.ParseMarkdown(fmt.Sprintf("## ERROR\n\n**Estado:** %s\n\n**Acción:** Prueba con una profundidad menor", err))
+				return
+			}
+			app.estadoActual = tablero
+			app.actualizarTablero()
+			app.reiniciarPartida()
+			app.infoLabel.ParseMarkdown(fmt.Sprintf("## PUZZLE MEZCLADO\n\n**Estado:** Configuración con solución óptima de %d movimientos\n\n**Semilla:** %d\n\n**Acción:** Selecciona algoritmo y presiona 'Resolver', o haz clic en las fichas junto al vacío para jugar", k, semilla))
+		})
+	}()
+}
+
+func mezclarADistancia(ctx context.Context, objetivo puzzle.Tablero, k int, rng *rand.Rand) (puzzle.Tablero, error) {
+	// mezclarADistancia elige un tablero a exactamente k movimientos del objetivo con
+	// puzzle.MezclarDistancia, usando la tabla de distancias de la caché en disco si el tablero
+	// es pequeño. Se ejecuta fuera de la goroutine de la interfaz.
+	var tabla *puzzle.TablaDistancias
+	if objetivo.Tamano() <= puzzle.MaxCeldasTablaDistancias {
+		tabla, _ = puzzle.ObtenerTablaDistancias(objetivo, nil) // Sin caché la tabla sigue siendo válida
+	}
+	return puzzle.MezclarDistancia(ctx, objetivo, k, tabla, rng)
 }
 
 func (app *PuzzleApp) resolver() {
 	// resolver ejecuta el algoritmo de búsqueda seleccionado para encontrar la solución al puzzle.
+	// La búsqueda corre en una goroutine con un contexto cancelable, de modo que la ventana sigue
+	// respondiendo y el botón CANCELAR puede abortarla; el resultado vuelve a la interfaz con fyne.Do.
 	// Mide el tiempo de ejecución y proporciona métricas de rendimiento al usuario.
+	// Antes de buscar verifica la resolubilidad y, si no hay solución, explica el motivo.
+	// Si se está editando el tablero, primero se confirma el borrador.
+	if app.modoEdicion {
+		app.confirmarEdicion()
+	}
+	if analisis := puzzle.AnalizarResolubilidad(app.estadoActual, app.objetivo); !analisis.Resoluble {
+		app.mostrarIrresoluble(analisis)
+		return
+	}
+
 	algoritmo_seleccionado := app.algoritmo.Selected
-	app.infoLabel.ParseMarkdown(fmt.Sprintf("## RESOLVIENDO PUZZLE\n\n**Algoritmo:** %s\n\n**Estado:** Buscando solución óptima...\n\n**Por favor espera**", algoritmo_seleccionado))
+	if algoritmo_seleccionado != algoritmoAnchura && app.heuristica.Selected == heuristicaPatrones && app.pdbs[app.objetivo] == nil {
+		// La base de patrones todavía no está disponible
+		app.prepararBasePatrones()
+		app.infoLabel.ParseMarkdown("## ADVERTENCIA\n\n**Estado:** La base de patrones aún se está construyendo\n\n**Acción:** Espera a que termine e intenta de nuevo")
+		return
+	}
+	var heuristica puzzle.Heuristica
+	if algoritmo_seleccionado != algoritmoAnchura {
+		// Los algoritmos informados se muestran junto con la heurística utilizada
+		heuristica = app.heuristicaSeleccionada()
+		algoritmo_seleccionado += " con " + app.heuristica.Selected
+	}
+	app.infoLabel.ParseMarkdown(fmt.Sprintf("## RESOLVIENDO PUZZLE\n\n**Algoritmo:** %s\n\n**Estado:** Buscando solución óptima...\n\n**Acción:** Presiona 'Cancelar' para detener la búsqueda", algoritmo_seleccionado))
+
+	// Descartar la solución anterior y bloquear RESOLVER mientras dure la búsqueda
+	app.detenerBusqueda()
+	app.limpiarSolucion()
+	ctx, cancelar := context.WithCancel(context.Background())
+	app.cancelar = cancelar
+	app.btnResolver.Disable()
+	app.btnCancelar.Enable()
+
+	// Copias de los datos que usa la goroutine: la interfaz puede modificarlos mientras tanto
+	algoritmo, inicial, objetivo := app.algoritmo.Selected, app.estadoActual, app.objetivo
+
+	// Los reportes de progreso llegan desde la goroutine de búsqueda varias veces por segundo
+	progreso := func(p puzzle.Progreso) {
+		fyne.Do(func() {
+			if ctx.Err() == nil {
+				app.mostrarProgreso(algoritmo_seleccionado, algoritmo, inicial.Tamano(), p)
+			}
+		})
+	}
 
-	// Medir tiempo de ejecución del algoritmo
-	inicio := time.Now()
+	go func() {
+		// Ejecutar el algoritmo seleccionado; cada búsqueda mide su propio tiempo de ejecución
+		var resultado puzzle.Resultado
+		var err error
+		switch algoritmo {
+		case algoritmoAEstrella:
+			resultado, err = puzzle.BusquedaAEstrella(ctx, inicial, objetivo, heuristica, progreso)
+		case algoritmoIDAEstrella:
+			resultado, err = puzzle.BusquedaIDAEstrella(ctx, inicial, objetivo, heuristica, progreso)
+		default:
+			resultado, err = puzzle.BusquedaAnchura(ctx, inicial, objetivo, progreso)
+		}
 
-	// Ejecutar el algoritmo seleccionado
-	if algoritmo_seleccionado == "A* con Heurística Manhattan" {
-		app.solucion = busquedaAEstrella(app.estadoActual, app.objetivo)
-	} else {
-		app.solucion = busquedaAnchura(app.estadoActual, app.objetivo)
+		fyne.Do(func() {
+			if err != nil || ctx.Err() != nil {
+				// Búsqueda cancelada: quien la canceló ya actualizó la interfaz
+				return
+			}
+			app.detenerBusqueda()
+			if app.cargarSolucion(resultado.Camino, heuristica) {
+				app.mostrarSolucion(algoritmo_seleccionado, resultado)
+			}
+		})
+	}()
+}
+
+func (app *PuzzleApp) mostrarProgreso(algoritmo_seleccionado, algoritmo string, celdas int, p puzzle.Progreso) {
+	// mostrarProgreso muestra el avance de la búsqueda en curso en el panel de información.
+	// La barra de progreso es una estimación: en BFS, la fracción de los estados alcanzables
+	// (celdas!/2) ya expandida; en A* e IDA*, la profundidad del último nodo respecto a f(n),
+	// que se acerca al 100% a medida que la búsqueda alcanza la profundidad de la solución.
+	avance := 0.0
+	if algoritmo == algoritmoAnchura {
+		alcanzables := 0.5
+		for k := 2; k <= celdas; k++ {
+			alcanzables *= float64(k)
+		}
+		avance = float64(p.Expandidos) / alcanzables
+	} else if p.MejorF > 0 {
+		avance = float64(p.Profundidad) / float64(p.MejorF)
 	}
+	app.progressBar.SetValue(avance)
 
-	duracion := time.Since(inicio)
+	app.infoLabel.ParseMarkdown(fmt.Sprintf("## RESOLVIENDO PUZZLE\n\n**Algoritmo:** %s\n\n**Nodos expandidos:** %d\n\n**Tamaño de la frontera:** %d\n\n**Mejor f(n):** %d\n\n**Profundidad actual:** %d\n\n**Tiempo transcurrido:** %.1f s\n\n**Acción:** Presiona 'Cancelar' para detener la búsqueda",
+		algoritmo_seleccionado, p.Expandidos, p.Frontera, p.MejorF, p.Profundidad, p.Transcurrido.Seconds()))
+}
 
+func (app *PuzzleApp) mostrarSolucion(algoritmo_seleccionado string, resultado puzzle.Resultado) {
+	// mostrarSolucion presenta en el panel de información el resultado de la búsqueda terminada
+	// junto con sus estadísticas: nodos expandidos y generados, tamaños máximos de la frontera
+	// y de la lista cerrada, duplicados podados, factor de ramificación efectivo y memoria estimada.
 	if len(app.solucion) > 0 {
-		// Solución encontrada - preparar para visualización paso a paso
-		app.paso = 0
-		app.progressBar.SetValue(0)
-
-		// Clasificar eficiencia basada en número de pasos
-		eficiencia := "Excelente"
-		if len(app.solucion) > 20 {
-			eficiencia = "Buena"
-		}
-		if len(app.solucion) > 30 {
-			eficiencia = "Promedio"
+		// Solución encontrada - la visualización paso a paso ya está preparada (ver cargarSolucion)
+
+		// Las iteraciones y cotas solo tienen sentido en IDA*
+		detalle := ""
+		if resultado.Iteraciones > 0 {
+			detalle = fmt.Sprintf("**Iteraciones:** %d\n\n**Cotas por iteración:** %v\n\n", resultado.Iteraciones, resultado.Cotas)
 		}
 
-		app.infoLabel.ParseMarkdown(fmt.Sprintf("## PUZZLE RESUELTO\n\n**Algoritmo:** %s\n\n**Pasos de solución:** %d\n\n**Tiempo de ejecución:** %d ms\n\n**Eficiencia:** %s\n\n**Acción:** Usa 'Paso a Paso' para ver la solución",
-			algoritmo_seleccionado, len(app.solucion)-1, duracion.Milliseconds(), eficiencia))
+		e := resultado.Estadisticas
+		app.infoLabel.ParseMarkdown(fmt.Sprintf("## PUZZLE RESUELTO\n\n**Algoritmo:** %s\n\n**Pasos de solución:** %d\n\n**Tiempo de ejecución:** %d ms\n\n%s"+
+			"**Nodos expandidos:** %d\n\n**Nodos generados:** %d\n\n**Frontera máxima:** %d\n\n**Lista cerrada máxima:** %d\n\n"+
+			"**Duplicados podados:** %d\n\n**Factor de ramificación efectivo:** %.3f\n\n**Memoria estimada:** %.1f KB\n\n"+
+			"**Acción:** Usa 'Paso a Paso', los botones de navegación o el deslizador para recorrer la solución",
+			algoritmo_seleccionado, len(app.solucion)-1, e.Tiempo.Milliseconds(), detalle,
+			e.NodosExpandidos, e.NodosGenerados, e.MaxFrontera, e.MaxCerrada,
+			e.DuplicadosPodados, e.FactorRamificacion, float64(e.MemoriaEstimada)/1024))
 	} else {
-		// No se encontró solución (caso improbable en 8-puzzle válido)
+		// No se encontró solución (no debería ocurrir tras verificar la resolubilidad)
 		app.infoLabel.ParseMarkdown("## ERROR\n\n**Estado:** No se encontró solución\n\n**Acción:** Intenta mezclar nuevamente")
 	}
 }
 
+func (app *PuzzleApp) detenerBusqueda() {
+	// detenerBusqueda cancela la búsqueda en segundo plano, si la hay, y restablece los botones.
+	// Se invoca al terminar una búsqueda y antes de cualquier acción que reemplace el tablero.
+	if app.cancelar != nil {
+		app.cancelar()
+		app.cancelar = nil
+	}
+	app.btnResolver.Enable()
+	app.btnCancelar.Disable()
+}
+
+func (app *PuzzleApp) cancelarBusqueda() {
+	// cancelarBusqueda atiende el botón CANCELAR: aborta la búsqueda en curso y lo informa.
+	if app.cancelar == nil {
+		return
+	}
+	app.detenerBusqueda()
+	app.infoLabel.ParseMarkdown("## BÚSQUEDA CANCELADA\n\n**Estado:** La búsqueda se detuvo a petición del usuario\n\n**Acción:** Presiona 'Resolver' para intentarlo de nuevo o elige otro algoritmo")
+}
+
+func (app *PuzzleApp) mostrarIrresoluble(analisis puzzle.AnalisisResolubilidad) {
+	// mostrarIrresoluble explica en el panel de información por qué el tablero actual no puede
+	// llevarse al objetivo: número de inversiones, fila del vacío y la regla de paridad aplicada.
+	filaVacio := "No interviene (columnas impares)"
+	if analisis.ColumnasPares {
+		filaVacio = fmt.Sprintf("%d (objetivo: %d)", analisis.FilaVacio+1, analisis.FilaVacioObjetivo+1)
+	}
+	app.infoLabel.ParseMarkdown(fmt.Sprintf("## PUZZLE SIN SOLUCIÓN\n\n**Inversiones:** %d\n\n**Fila del vacío:** %s\n\n**Paridad:** %d (debe ser par)\n\n**Explicación:** %s\n\n**Acción:** Presiona 'Mezclar' o 'Iniciar' para obtener un tablero resoluble",
+		analisis.Inversiones, filaVacio, analisis.Paridad, analisis.Explicacion))
+}
+
+func (app *PuzzleApp) cargarSolucion(camino []puzzle.Estado, heuristica puzzle.Heuristica) bool {
+	// cargarSolucion prepara la visualización de la solución encontrada: registra sus pasos en la
+	// sesión como movimientos por rehacer, calcula h(n) en cada paso con la heurística de la
+	// búsqueda (Manhattan para BFS) y ubica el deslizador en el tablero inicial.
+	//
+	// RETORNA: false si la solución no parte del tablero vigente de la sesión (informado en el
+	// panel de información); en ese caso no se carga nada
+	if len(camino) > 0 && !app.sesion.CargarCamino(camino) {
+		app.limpiarSolucion()
+		app.infoLabel.ParseMarkdown("## ERROR\n\n**Estado:** La solución encontrada no parte del tablero actual\n\n**Acción:** Presiona 'Resolver' para buscarla de nuevo")
+		return false
+	}
+	if heuristica == nil {
+		heuristica = puzzle.NuevaHeuristicaManhattan(app.objetivo)
+	}
+	app.solucion = camino
+	app.paso = 0
+	app.hSolucion = make([]int, len(camino))
+	for i, estado := range camino {
+		app.hSolucion[i] = heuristica(estado.Tablero)
+	}
+	app.actualizarPartida()
+
+	app.deslizador.Max = float64(max(len(camino)-1, 1))
+	app.deslizador.Value = 0
+	app.deslizador.Enable()
+	app.actualizarNavegacion()
+	return true
+}
+
+func (app *PuzzleApp) limpiarSolucion() {
+	// limpiarSolucion descarta la solución cargada y restablece la barra de progreso y el
+	// deslizador. Se invoca cuando el tablero cambia por cualquier otro medio.
+	app.pausar()
+	app.solucion = []puzzle.Estado{}
+	app.hSolucion = nil
+	app.paso = 0
+	app.progressBar.SetValue(0)
+	app.deslizador.Disable()
+	app.pasoLabel.SetText("Paso -/-")
+}
+
+func (app *PuzzleApp) actualizarNavegacion() {
+	// actualizarNavegacion sincroniza la barra de progreso, el deslizador y la etiqueta de paso
+	// con app.paso, el índice del paso mostrado (0 es el tablero inicial).
+	total := len(app.solucion) - 1
+	progreso := 1.0
+	if total > 0 {
+		progreso = float64(app.paso) / float64(total)
+	}
+	app.progressBar.SetValue(progreso)
+	app.deslizador.SetValue(float64(app.paso)) // Sin efecto si ya está en ese valor
+	app.pasoLabel.SetText(fmt.Sprintf("Paso %d/%d: %s (h=%d)", app.paso, total, app.accionPaso(app.paso), app.hSolucion[app.paso]))
+}
+
+func (app *PuzzleApp) accionPaso(paso int) string {
+	// accionPaso retorna el nombre del movimiento que lleva al paso indicado de la solución.
+	if paso == 0 {
+		return "Estado inicial"
+	}
+	return app.solucion[paso].Accion
+}
+
+func (app *PuzzleApp) mostrarPaso() {
+	// mostrarPaso presenta el paso actual de la solución en la barra de progreso, el deslizador
+	// y el panel de información, con la acción aplicada y el valor de h(n) del tablero.
+	app.actualizarNavegacion()
+	total := len(app.solucion) - 1
+	app.infoLabel.ParseMarkdown(fmt.Sprintf("## EJECUTANDO SOLUCIÓN\n\n**Paso:** %d de %d\n\n**Movimiento:** %s\n\n**h(n):** %d\n\n**Progreso:** %.1f%%",
+		app.paso, total, app.accionPaso(app.paso), app.hSolucion[app.paso], app.progressBar.Value*100))
+}
+
 func (app *PuzzleApp) siguientePaso() {
 	// siguientePaso avanza un paso en la visualización de la solución encontrada.
 	// Implementa animación para mostrar qué pieza se mueve en cada transición.
@@ -643,59 +1227,144 @@ func (app *PuzzleApp) siguientePaso() {
 		return
 	}
 
-	if app.paso < len(app.solucion) {
-		estadoNuevo := app.solucion[app.paso].tablero
+	if app.paso == len(app.solucion)-1 {
+		// Solución completada
+		app.progressBar.SetValue(1.0)
+		app.infoLabel.ParseMarkdown("## SOLUCIÓN COMPLETADA\n\n**Estado:** Puzzle resuelto exitosamente\n\n**Felicitaciones:** El algoritmo funcionó correctamente\n\n**Acción:** Usa 'Anterior', 'Primero' o el deslizador para repasar la solución")
+		return
+	}
 
-		// Actualizar barra de progreso
-		progreso := float64(app.paso) / float64(len(app.solucion)-1)
-		app.progressBar.SetValue(progreso)
+	// Encontrar y destacar la pieza que se mueve para feedback visual
+	retardo := app.retardo()
+	anterior := app.estadoActual
+	estadoNuevo := app.solucion[app.paso+1].Tablero
+	for i := range app.botones {
+		if anterior.Valor(i) != 0 && anterior.Valor(i) != estadoNuevo.Valor(i) {
+			app.botones[i].destacar(retardo)
+			break
+		}
+	}
 
-		// Determinar la acción realizada
-		accion := "Estado inicial"
-		if app.paso > 0 {
-			accion = app.solucion[app.paso].accion
+	// Registrar el paso en el historial para poder deshacerlo. El estado se actualiza en el acto
+	// (los movimientos con el teclado y los clics parten de él); solo el redibujado del tablero
+	// espera un pequeño delay para permitir la animación (300ms con la velocidad predeterminada)
+	app.paso++
+	app.sesion.Mover(app.solucion[app.paso].Accion)
+	app.estadoActual = app.sesion.Tablero()
+	app.actualizarPartida()
+	app.mostrarPaso()
+	go func() {
+		time.Sleep(retardo * 3 / 8)
+		fyne.DoAndWait(app.actualizarTablero)
+	}()
+}
 
-			// Encontrar y destacar la pieza que se mueve para feedback visual
-			for i := 0; i < 9; i++ {
-				if app.estadoActual[i] != 0 && app.estadoActual[i] != estadoNuevo[i] {
-					app.botones[i].destacar()
-					break
-				}
-			}
+func (app *PuzzleApp) irAPaso(paso int) {
+	// irAPaso muestra directamente el paso indicado de la solución (0 es el tablero inicial),
+	// sin animación. Recorre el historial de la sesión, de modo que deshacer y rehacer siguen
+	// correspondiendo a los pasos anterior y siguiente.
+	if len(app.solucion) == 0 || paso < 0 || paso >= len(app.solucion) || paso == app.paso {
+		return
+	}
+	app.pausar() // La navegación manual detiene la reproducción automática
+	for app.paso > paso {
+		app.sesion.Deshacer()
+		app.paso--
+	}
+	for app.paso < paso {
+		app.paso++
+		app.sesion.Mover(app.solucion[app.paso].Accion)
+	}
+	app.estadoActual = app.sesion.Tablero()
+	app.actualizarTablero()
+	app.actualizarPartida()
+	app.mostrarPaso()
+}
 
-			// Actualizar el tablero después de un pequeño delay para permitir la animación
-			go func(nuevoEstado [9]int) {
-				time.Sleep(300 * time.Millisecond)
-				fyne.DoAndWait(func() {
-					app.estadoActual = nuevoEstado
-					app.actualizarTablero()
-				})
-			}(estadoNuevo)
-		} else {
-			// Primer paso: actualización directa sin animación
-			app.estadoActual = estadoNuevo
-			app.actualizarTablero()
-		}
+func (app *PuzzleApp) retardo() time.Duration {
+	// retardo retorna el tiempo entre dos movimientos según el deslizador de velocidad
+	// (movimientos por segundo). Con la velocidad predeterminada de 1.25 son 800ms.
+	return time.Duration(float64(time.Second) / app.velocidad.Value)
+}
 
-		// Actualizar información del paso actual
-		app.infoLabel.ParseMarkdown(fmt.Sprintf("## EJECUTANDO SOLUCIÓN\n\n**Paso:** %d de %d\n\n**Movimiento:** %s\n\n**Progreso:** %.1f%%",
-			app.paso+1, len(app.solucion), accion, progreso*100))
+func (app *PuzzleApp) reproducir() {
+	// reproducir atiende el botón REPRODUCIR/PAUSA: inicia o detiene el avance automático de la
+	// solución, un paso cada app.retardo(). Si la solución ya se mostró completa, comienza de
+	// nuevo desde el primer paso. La reproducción se detiene sola al llegar al objetivo y
+	// al reemplazarse la solución (mezclar, resolver, iniciar o mover una ficha).
+	if app.detenerReproduccion != nil {
+		app.pausar()
+		return
+	}
+	if len(app.solucion) == 0 {
+		app.infoLabel.ParseMarkdown("## ADVERTENCIA\n\n**Estado:** No hay solución cargada\n\n**Acción:** Primero resuelve el puzzle")
+		return
+	}
+	if app.paso == len(app.solucion)-1 {
+		app.primerPaso()
+	}
 
-		app.paso++
-	} else {
-		// Solución completada
-		app.progressBar.SetValue(1.0)
-		app.infoLabel.ParseMarkdown("## SOLUCIÓN COMPLETADA\n\n**Estado:** Puzzle resuelto exitosamente\n\n**Felicitaciones:** El algoritmo funcionó correctamente")
+	ctx, detener := context.WithCancel(context.Background())
+	app.detenerReproduccion = detener
+	app.btnReproducir.SetText("PAUSA")
+	app.btnReproducir.SetIcon(theme.MediaPauseIcon())
+
+	retardo := app.retardo()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retardo):
+			}
+			fyne.DoAndWait(func() {
+				if ctx.Err() != nil {
+					return // Pausada mientras se esperaba
+				}
+				app.siguientePaso()
+				if app.paso == len(app.solucion)-1 {
+					app.pausar()
+				}
+				retardo = app.retardo() // La velocidad puede cambiar durante la reproducción
+			})
+		}
+	}()
+}
+
+func (app *PuzzleApp) pausar() {
+	// pausar detiene la reproducción automática, si está en curso, y restablece el botón.
+	if app.detenerReproduccion == nil {
+		return
 	}
+	app.detenerReproduccion()
+	app.detenerReproduccion = nil
+	app.btnReproducir.SetText("REPRODUCIR")
+	app.btnReproducir.SetIcon(theme.MediaPlayIcon())
+}
+
+func (app *PuzzleApp) pasoAnterior() {
+	// pasoAnterior retrocede un paso en la visualización de la solución.
+	app.irAPaso(app.paso - 1)
+}
+
+func (app *PuzzleApp) primerPaso() {
+	// primerPaso vuelve al tablero inicial de la solución.
+	app.irAPaso(0)
+}
+
+func (app *PuzzleApp) ultimoPaso() {
+	// ultimoPaso salta al tablero final de la solución (el objetivo).
+	app.irAPaso(len(app.solucion) - 1)
 }
 
 func main() {
 	// main es la función principal que inicializa y ejecuta la aplicación gráfica.
 	//
 	// FLUJO DE EJECUCIÓN:
+	// 0. Si recibe un subcomando, lo ejecuta en modo línea de comandos y termina
 	// 1. Crea la aplicación Fyne y configura la ventana principal
 	// 2. Construye el header con información institucional
-	// 3. Inicializa la cuadrícula 3x3 del puzzle con botones personalizados
+	// 3. Inicializa la cuadrícula FxC del puzzle con botones personalizados (3x3 por defecto)
 	// 4. Configura los controles de interacción (algoritmo, botones, progreso)
 	// 5. Ensambla el layout completo usando containers de Fyne
 	// 6. Inicializa el puzzle en estado ordenado
@@ -712,6 +1381,11 @@ func main() {
 	// Implementa el patrón Observer donde la UI reacciona a cambios en el modelo de datos
 	// Utiliza el patrón Command para encapsular acciones de usuario en métodos
 
+	// Con un subcomando (solve, shuffle, bench, check, table) ejecutar sin abrir la ventana
+	if len(os.Args) > 1 && cli.EsSubcomando(os.Args[1]) {
+		os.Exit(cli.Ejecutar(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	// Crear aplicación Fyne con tema personalizado mejorado
 	myApp := app.New()
 	myApp.Settings().SetTheme(&MyTheme{}) // Activar tema personalizado
@@ -746,52 +1420,95 @@ func main() {
 	header := container.NewStack(headerCard, headerContent)
 
 	// Crear cuadrícula interactiva del puzzle con espaciado óptimo
-	cuadricula := container.NewGridWithColumns(3)
-	for i := 0; i < 9; i++ {
-		btn := NewPuzzleButton(1) // Inicializar con número temporal
-		btn.Resize(fyne.NewSize(100, 100))
-
-		// Configuración inicial del tablero ordenado
-		if i < 8 {
-			// Números del 1 al 8
-			btn.SetText(strconv.Itoa(i + 1))
-			btn.Importance = widget.HighImportance
-		} else {
-			// Espacio vacío (sin mostrar número 0)
-			btn.SetText("")
-			btn.Importance = widget.LowImportance
-		}
-		btn.Refresh()
-
-		puzzleApp.botones[i] = btn
-		cuadricula.Add(btn)
-	}
+	puzzleApp.cuadricula = container.NewGridWithColumns(puzzleApp.columnas)
+	puzzleApp.construirCuadricula()
 
 	// Contenedor con fondo cálido para la cuadrícula
 	puzzleCard := canvas.NewRectangle(color.NRGBA{255, 251, 247, 255}) // Crema muy claro
-	puzzleCardContainer := container.NewStack(puzzleCard, cuadricula)
+	puzzleCardContainer := container.NewStack(puzzleCard, puzzleApp.cuadricula)
 
 	// Panel de estado en tiempo real
 	puzzleApp.estadoLabel = widget.NewLabel("")
 	puzzleApp.estadoLabel.Alignment = fyne.TextAlignCenter
 	puzzleApp.estadoLabel.TextStyle.Bold = true
 
+	// Contador y reloj de la partida manual (clic en las fichas adyacentes al vacío)
+	puzzleApp.partidaLabel = widget.NewLabel("")
+	puzzleApp.partidaLabel.Alignment = fyne.TextAlignCenter
+
 	// Barra de progreso para visualización paso a paso
 	puzzleApp.progressBar = widget.NewProgressBar()
 	puzzleApp.progressBar.TextFormatter = func() string {
 		return fmt.Sprintf("Progreso: %.0f%%", puzzleApp.progressBar.Value*100)
 	}
 
+	// Selectores de filas y columnas (2 a 5 cada una); reconstruyen la cuadrícula de botones
+	dimensiones := []string{}
+	for n := puzzle.DimensionMinima; n <= puzzle.DimensionMaxima; n++ {
+		dimensiones = append(dimensiones, strconv.Itoa(n))
+	}
+	puzzleApp.selFilas = widget.NewSelect(dimensiones, func(seleccion string) {
+		if filas, err := strconv.Atoi(seleccion); err == nil {
+			puzzleApp.cambiarTamano(filas, puzzleApp.columnas)
+		}
+	})
+	puzzleApp.selColumnas = widget.NewSelect(dimensiones, func(seleccion string) {
+		if columnas, err := strconv.Atoi(seleccion); err == nil {
+			puzzleApp.cambiarTamano(puzzleApp.filas, columnas)
+		}
+	})
+
 	// Selector de algoritmo de búsqueda
-	etiquetaAlgoritmo := widget.NewLabel("ALGORITMO DE BÚSQUEDA")
+	etiquetaAlgoritmo := widget.NewLabel("ALGORITMO DE BÚSQUEDA Y HEURÍSTICA")
 	etiquetaAlgoritmo.TextStyle.Bold = true
 	etiquetaAlgoritmo.Alignment = fyne.TextAlignCenter
 
+	// Selector de heurística; se deshabilita para BFS, que es una búsqueda no informada
+	puzzleApp.heuristica = widget.NewSelect(
+		[]string{heuristicaManhattan, heuristicaConflictoLineal, heuristicaPatrones},
+		func(seleccion string) {
+			if seleccion == heuristicaPatrones {
+				puzzleApp.prepararBasePatrones()
+			}
+		},
+	)
+	puzzleApp.heuristica.SetSelected(heuristicaManhattan)
+
 	puzzleApp.algoritmo = widget.NewSelect(
-		[]string{"A* con Heurística Manhattan", "Búsqueda en Anchura (BFS)"},
-		nil,
+		[]string{algoritmoAEstrella, algoritmoIDAEstrella, algoritmoAnchura},
+		func(seleccion string) {
+			if seleccion == algoritmoAnchura {
+				puzzleApp.heuristica.Disable()
+			} else {
+				puzzleApp.heuristica.Enable()
+			}
+		},
 	)
-	puzzleApp.algoritmo.SetSelected("A* con Heurística Manhattan")
+	puzzleApp.algoritmo.SetSelected(algoritmoAEstrella)
+
+	// Editor de objetivo: los predefinidos se aplican al seleccionarse; el personalizado
+	// se escribe en el campo de texto y se aplica con el botón
+	puzzleApp.textoObjetivo = widget.NewEntry()
+	puzzleApp.textoObjetivo.SetPlaceHolder("Objetivo, por ejemplo: 1 2 3 8 0 4 7 6 5")
+	btnObjetivo := widget.NewButton("APLICAR OBJETIVO", func() {
+		puzzleApp.tipoObjetivo.SetSelected(objetivoPersonalizado)
+		puzzleApp.aplicarObjetivo()
+	})
+	puzzleApp.tipoObjetivo = widget.NewSelect(
+		[]string{objetivoEstandar, objetivoVacioInicial, objetivoEspiral, objetivoPersonalizado},
+		func(seleccion string) {
+			if seleccion != objetivoPersonalizado {
+				puzzleApp.aplicarObjetivo()
+			}
+		},
+	)
+
+	// Editor del tablero inicial: el campo acepta un tablero escrito o pegado y el botón
+	// alterna el modo de edición, en el que los clics intercambian casillas
+	puzzleApp.textoTablero = widget.NewEntry()
+	puzzleApp.textoTablero.SetPlaceHolder("Tablero, por ejemplo: 1 2 3 4 0 6 7 5 8")
+	puzzleApp.textoTablero.OnChanged = puzzleApp.editarTexto
+	puzzleApp.btnEditar = widget.NewButton("EDITAR TABLERO", puzzleApp.alternarEdicion)
 
 	// Botones de control principal con paleta cálida
 	btnIniciar := widget.NewButton("INICIAR", puzzleApp.iniciar)
@@ -800,12 +1517,56 @@ func main() {
 	btnMezclar := widget.NewButton("MEZCLAR", puzzleApp.mezclar)
 	btnMezclar.Importance = widget.HighImportance // Naranja terracota para acción principal
 
-	btnResolver := widget.NewButton("RESOLVER", puzzleApp.resolver)
-	btnResolver.Importance = widget.SuccessImportance // Verde musgo para acción positiva
+	// Mezcla a una profundidad óptima exacta, verificada con IDA*
+	puzzleApp.profundidad = widget.NewEntry()
+	puzzleApp.profundidad.SetText("20")
+	btnMezclarExacto := widget.NewButton("MEZCLAR EXACTO", puzzleApp.mezclarExacto)
+	puzzleApp.semilla = widget.NewEntry()
+	puzzleApp.semilla.SetPlaceHolder("Aleatoria")
+	puzzleApp.modoMezcla = widget.NewSelect([]string{mezclaCaminata, mezclaUniforme}, nil)
+	puzzleApp.modoMezcla.SetSelected(mezclaCaminata)
+
+	puzzleApp.btnResolver = widget.NewButton("RESOLVER", puzzleApp.resolver)
+	puzzleApp.btnResolver.Importance = widget.SuccessImportance // Verde musgo para acción positiva
+
+	puzzleApp.btnCancelar = widget.NewButton("CANCELAR", puzzleApp.cancelarBusqueda)
+	puzzleApp.btnCancelar.Importance = widget.DangerImportance // Café rojizo para detener la búsqueda
+	puzzleApp.btnCancelar.Disable()
+
+	puzzleApp.btnDeshacer = widget.NewButton("DESHACER", puzzleApp.deshacer)
+	puzzleApp.btnDeshacer.Importance = widget.LowImportance
+	puzzleApp.btnDeshacer.Disable()
+
+	puzzleApp.btnRehacer = widget.NewButton("REHACER", puzzleApp.rehacer)
+	puzzleApp.btnRehacer.Importance = widget.LowImportance
+	puzzleApp.btnRehacer.Disable()
 
 	btnPaso := widget.NewButton("PASO A PASO", puzzleApp.siguientePaso)
 	btnPaso.Importance = widget.WarningImportance // Naranja cálido para visualización
 
+	// Navegación por la solución: primero, anterior, último y deslizador para saltar a cualquier paso
+	btnPrimero := widget.NewButtonWithIcon("PRIMERO", theme.MediaSkipPreviousIcon(), puzzleApp.primerPaso)
+	btnAnterior := widget.NewButtonWithIcon("ANTERIOR", theme.NavigateBackIcon(), puzzleApp.pasoAnterior)
+	btnUltimo := widget.NewButtonWithIcon("ÚLTIMO", theme.MediaSkipNextIcon(), puzzleApp.ultimoPaso)
+	puzzleApp.btnReproducir = widget.NewButtonWithIcon("REPRODUCIR", theme.MediaPlayIcon(), puzzleApp.reproducir)
+	puzzleApp.btnReproducir.Importance = widget.WarningImportance
+	puzzleApp.pasoLabel = widget.NewLabel("Paso -/-")
+	puzzleApp.deslizador = widget.NewSlider(0, 1)
+	puzzleApp.deslizador.Step = 1
+	puzzleApp.deslizador.OnChanged = func(valor float64) {
+		puzzleApp.irAPaso(int(valor))
+	}
+	puzzleApp.deslizador.Disable()
+
+	// Velocidad de la reproducción automática y de las animaciones (0.5 a 10 movimientos por segundo)
+	etiquetaVelocidad := widget.NewLabel("")
+	puzzleApp.velocidad = widget.NewSlider(0.5, 10)
+	puzzleApp.velocidad.Step = 0.25
+	puzzleApp.velocidad.OnChanged = func(valor float64) {
+		etiquetaVelocidad.SetText(fmt.Sprintf("%.2f mov/s", valor))
+	}
+	puzzleApp.velocidad.SetValue(1.25) // 800ms entre movimientos
+
 	// Panel de información detallada con texto enriquecido
 	etiquetaInfo := widget.NewLabel("INFORMACIÓN DEL SISTEMA")
 	etiquetaInfo.TextStyle.Bold = true
@@ -828,25 +1589,48 @@ func main() {
 	etiquetaPaso2.Alignment = fyne.TextAlignCenter
 
 	// Fila 1: Configuración inicial
-	filaConfiguracion := container.NewGridWithColumns(2,
-		btnIniciar, btnMezclar,
+	filaConfiguracion := container.NewGridWithColumns(4,
+		btnIniciar, btnMezclar, puzzleApp.btnDeshacer, puzzleApp.btnRehacer,
 	)
 
 	// Fila 2: Resolución y visualización
-	filaResolucion := container.NewGridWithColumns(2,
-		btnResolver, btnPaso,
+	filaResolucion := container.NewGridWithColumns(3,
+		puzzleApp.btnResolver, puzzleApp.btnCancelar, btnPaso,
 	)
 
+	// Fila 3: Navegación por la solución
+	filaNavegacion := container.NewGridWithColumns(4,
+		btnPrimero, btnAnterior, puzzleApp.btnReproducir, btnUltimo,
+	)
+	filaDeslizador := container.NewBorder(nil, nil, puzzleApp.pasoLabel, nil, puzzleApp.deslizador)
+	filaVelocidad := container.NewBorder(nil, nil, widget.NewLabel("Velocidad:"), etiquetaVelocidad, puzzleApp.velocidad)
+
 	// Panel de controles reorganizado para mejor UX
 	controles := container.NewVBox(
 		etiquetaAlgoritmo,
-		puzzleApp.algoritmo,
+		container.NewGridWithColumns(4,
+			widget.NewLabel("Filas:"), puzzleApp.selFilas,
+			widget.NewLabel("Columnas:"), puzzleApp.selColumnas,
+		),
+		container.NewGridWithColumns(2, puzzleApp.algoritmo, puzzleApp.heuristica),
+		container.NewBorder(nil, nil, widget.NewLabel("Objetivo:"), btnObjetivo,
+			container.NewGridWithColumns(2, puzzleApp.tipoObjetivo, puzzleApp.textoObjetivo),
+		),
+		container.NewBorder(nil, nil, widget.NewLabel("Tablero:"), puzzleApp.btnEditar, puzzleApp.textoTablero),
 		widget.NewSeparator(),
 		etiquetaPaso1,
 		filaConfiguracion,
+		container.NewGridWithColumns(2,
+			container.NewBorder(nil, nil, widget.NewLabel("Mezcla:"), nil, puzzleApp.modoMezcla),
+			container.NewBorder(nil, nil, widget.NewLabel("Semilla:"), nil, puzzleApp.semilla),
+		),
+		container.NewBorder(nil, nil, widget.NewLabel("Movimientos óptimos:"), btnMezclarExacto, puzzleApp.profundidad),
 		widget.NewSeparator(),
 		etiquetaPaso2,
 		filaResolucion,
+		filaNavegacion,
+		filaDeslizador,
+		filaVelocidad,
 		widget.NewSeparator(),
 		puzzleApp.progressBar,
 	)
@@ -860,6 +1644,7 @@ func main() {
 	// Layout principal vertical
 	mainContent := container.NewVBox(
 		puzzleApp.estadoLabel,
+		puzzleApp.partidaLabel,
 		puzzleCardContainer,
 		widget.NewSeparator(),
 		controles,
@@ -874,8 +1659,18 @@ func main() {
 	)
 
 	ventana.SetContent(content)
+	puzzleApp.registrarTeclado()
 
 	// Inicializar en estado ordenado y comenzar loop de eventos
-	puzzleApp.iniciar()
+	puzzleApp.tipoObjetivo.SetSelected(objetivoEstandar)
+	puzzleApp.selFilas.SetSelected(strconv.Itoa(puzzleApp.filas))
+	puzzleApp.selColumnas.SetSelected(strconv.Itoa(puzzleApp.columnas))
+
+	// Refrescar el reloj de la partida manual una vez por segundo
+	go func() {
+		for range time.Tick(time.Second) {
+			fyne.Do(puzzleApp.actualizarPartida)
+		}
+	}()
 	ventana.ShowAndRun()
 }
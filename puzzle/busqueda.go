@@ -0,0 +1,246 @@
+package puzzle
+
+import (
+	"context"
+	"time"
+)
+
+func BusquedaAEstrella(ctx context.Context, inicial Tablero, objetivo Tablero, heuristica Heuristica, progreso func(Progreso)) (Resultado, error) {
+	// BusquedaAEstrella implementa el algoritmo A* (A-estrella) para encontrar la solución óptima.
+	//
+	// ALGORITMO A*:
+	// 1. Mantiene dos listas: ABIERTA (nodos por explorar) y CERRADA (nodos explorados)
+	// 2. Selecciona el nodo con menor f(n) = g(n) + h(n) de la lista ABIERTA (montículo binario,
+	//    empates resueltos por menor h(n) y luego por orden de inserción)
+	// 3. Si es el objetivo, reconstruye y retorna el camino
+	// 4. Si no, expande sus sucesores y los agrega a ABIERTA si no están en CERRADA; las copias
+	//    repetidas de un tablero en ABIERTA se descartan al extraerse (eliminación perezosa)
+	// 5. Repite hasta encontrar solución o agotar posibilidades
+	//
+	// PROPIEDADES:
+	// - Completitud: Siempre encuentra solución si existe
+	// - Optimalidad: Garantiza la solución de menor costo con heurística admisible
+	// - Complejidad temporal: O(b^d) donde b=factor ramificación, d=profundidad solución
+	// - Complejidad espacial: O(b^d) para almacenar nodos en memoria
+	//
+	// PARÁMETROS:
+	// - ctx: contexto de cancelación; la búsqueda se detiene poco después de cancelarse
+	// - inicial: configuración inicial del tablero (Tablero)
+	// - objetivo: configuración objetivo del tablero (Tablero)
+	// - heuristica: función heurística admisible, por ejemplo NuevaHeuristicaManhattan(objetivo)
+	// - progreso: función opcional (puede ser nil) que recibe el avance cada IntervaloProgreso
+	//
+	// RETORNA: Resultado con el camino solución (vacío si no hay solución) y las estadísticas,
+	// y el error del contexto si la búsqueda fue cancelada
+	inicio := time.Now()
+	resultado := Resultado{Camino: []Estado{}}
+	estadisticas := &resultado.Estadisticas
+
+	// Verificar la paridad antes de buscar: un tablero irresoluble agotaría sin éxito
+	// toda su mitad del espacio de estados
+	if !EsResoluble(inicial, objetivo) {
+		estadisticas.completar(resultado.Camino, inicio)
+		return resultado, nil
+	}
+
+	// Inicializar lista ABIERTA (montículo binario ordenado por f) con el estado inicial
+	abierta := &colaPrioridad{}
+	abierta.insertar(&Estado{Tablero: inicial, Costo: 0, Estimacion: heuristica(inicial)})
+	estadisticas.MaxFrontera = 1
+	// Lista CERRADA (conjunto hash con consulta O(1)) para evitar reexplorar estados
+	cerrada := NuevoConjuntoEstados()
+
+	// Los nodos expandidos son exactamente los de la lista CERRADA
+	monitor := nuevoMonitor(ctx, progreso)
+	avance := func(actual *Estado) Progreso {
+		return Progreso{
+			Expandidos:  cerrada.Tamano(),
+			Frontera:    abierta.Len(),
+			MejorF:      actual.Costo + actual.Estimacion,
+			Profundidad: actual.Costo,
+		}
+	}
+	// terminar registra las estadísticas finales; cada nodo de CERRADA y de ABIERTA es un Estado en memoria
+	terminar := func(actual *Estado, camino []Estado) {
+		monitor.finalizar(avance(actual))
+		estadisticas.NodosExpandidos = cerrada.Tamano()
+		estadisticas.MaxCerrada = cerrada.Tamano()
+		estadisticas.MemoriaEstimada = int64(estadisticas.MaxCerrada)*(bytesEstado+bytesEntradaCerrada) +
+			int64(estadisticas.MaxFrontera)*(bytesEstado+bytesNodoCola)
+		resultado.Camino = camino
+		estadisticas.completar(camino, inicio)
+	}
+
+	actual := &Estado{}
+	for abierta.Len() > 0 {
+		// Extraer el nodo con menor f(n) = g(n) + h(n) de la lista ABIERTA en O(log n)
+		actual = abierta.extraer()
+
+		// Eliminación perezosa: un mismo tablero puede estar varias veces en ABIERTA;
+		// solo se expande la primera copia extraída (la de menor f) y el resto se descarta
+		if cerrada.Contiene(actual.Tablero) {
+			estadisticas.DuplicadosPodados++
+			continue
+		}
+
+		// Atender la cancelación y reportar el progreso periódicamente
+		if monitor.pulso() {
+			if err := monitor.revisar(avance(actual)); err != nil {
+				terminar(actual, []Estado{})
+				return resultado, err
+			}
+		}
+
+		// Verificar si alcanzamos el estado objetivo
+		if EsObjetivo(actual.Tablero, objetivo) {
+			terminar(actual, reconstruirCamino(actual))
+			return resultado, nil
+		}
+
+		// Agregar el estado actual a la lista CERRADA
+		cerrada.Agregar(actual.Tablero)
+
+		// Generar y evaluar todos los sucesores del estado actual
+		for _, movimiento := range GenerarMovimientos(actual.Tablero) {
+			estadisticas.NodosGenerados++
+			// Si no está explorado (no está en CERRADA), agregarlo a la lista ABIERTA
+			if cerrada.Contiene(movimiento.Tablero) {
+				estadisticas.DuplicadosPodados++
+				continue
+			}
+			sucesor := movimiento
+			sucesor.Padre = actual
+			sucesor.Costo = actual.Costo + 1
+			sucesor.Estimacion = heuristica(sucesor.Tablero) // h(n) se calcula una sola vez por nodo
+			abierta.insertar(&sucesor)
+		}
+		estadisticas.MaxFrontera = max(estadisticas.MaxFrontera, abierta.Len())
+	}
+
+	terminar(actual, []Estado{}) // Camino vacío: no hay solución
+	return resultado, nil
+}
+
+func BusquedaAnchura(ctx context.Context, inicial Tablero, objetivo Tablero, progreso func(Progreso)) (Resultado, error) {
+	// BusquedaAnchura implementa el algoritmo de Búsqueda en Anchura (BFS) para resolver el puzzle.
+	//
+	// ALGORITMO BFS:
+	// 1. Utiliza una cola FIFO (First In, First Out) para explorar nodos nivel por nivel
+	// 2. Explora todos los nodos a profundidad d antes de explorar nodos a profundidad d+1
+	// 3. Mantiene lista de visitados para evitar ciclos infinitos
+	// 4. Garantiza encontrar la solución con menor número de movimientos
+	//
+	// PROPIEDADES:
+	// - Completitud: Siempre encuentra solución si existe y el espacio es finito
+	// - Optimalidad: Garantiza solución óptima en número de movimientos (costo uniforme)
+	// - Complejidad temporal: O(b^d) donde b=factor ramificación, d=profundidad solución
+	// - Complejidad espacial: O(b^d) para almacenar todos los nodos de cada nivel
+	//
+	// DIFERENCIAS CON A*:
+	// - No usa heurística (búsqueda ciega)
+	// - Explora más nodos que A* en promedio
+	// - Útil cuando no se dispone de heurística admisible
+	// - Mejor para problemas donde todos los movimientos tienen el mismo costo
+	//
+	// PARÁMETROS:
+	// - ctx: contexto de cancelación; la búsqueda se detiene poco después de cancelarse
+	// - inicial: configuración inicial del tablero (Tablero)
+	// - objetivo: configuración objetivo del tablero (Tablero)
+	// - progreso: función opcional (puede ser nil) que recibe el avance cada IntervaloProgreso
+	//
+	// RETORNA: Resultado con el camino solución (vacío si no hay solución) y las estadísticas,
+	// y el error del contexto si la búsqueda fue cancelada
+	inicio := time.Now()
+	resultado := Resultado{Camino: []Estado{}}
+	estadisticas := &resultado.Estadisticas
+
+	// Verificar la paridad antes de buscar: sin esta comprobación un tablero irresoluble
+	// recorre todos sus estados alcanzables (181.440 en el 8-puzzle) antes de rendirse
+	if !EsResoluble(inicial, objetivo) {
+		estadisticas.completar(resultado.Camino, inicio)
+		return resultado, nil
+	}
+
+	// Inicializar cola FIFO con el estado inicial
+	cola := []*Estado{{Tablero: inicial}}
+	// Conjunto de estados visitados (consulta O(1)) para evitar ciclos; un estado se marca
+	// al encolarse para que nunca entre dos veces en la cola
+	visitados := NuevoConjuntoEstados()
+	visitados.Agregar(inicial)
+	estadisticas.MaxFrontera = 1
+
+	// En BFS el menor f(n) pendiente es el nivel que se está explorando (f = g)
+	monitor := nuevoMonitor(ctx, progreso)
+	avance := func(actual *Estado) Progreso {
+		return Progreso{
+			Expandidos:  estadisticas.NodosExpandidos,
+			Frontera:    len(cola),
+			MejorF:      actual.Costo,
+			Profundidad: actual.Costo,
+		}
+	}
+	// terminar registra las estadísticas finales; cada estado visitado es un Estado en memoria
+	terminar := func(actual *Estado, camino []Estado) {
+		monitor.finalizar(avance(actual))
+		estadisticas.MaxCerrada = visitados.Tamano()
+		estadisticas.MemoriaEstimada = int64(estadisticas.MaxCerrada)*(bytesEstado+bytesEntradaCerrada) +
+			int64(estadisticas.MaxFrontera)*bytesPuntero
+		resultado.Camino = camino
+		estadisticas.completar(camino, inicio)
+	}
+
+	actual := cola[0]
+	for len(cola) > 0 {
+		// Extraer el primer elemento de la cola (FIFO)
+		actual = cola[0]
+		cola[0] = nil // Liberar la referencia para que el recolector pueda reclamar nodos descartados
+		cola = cola[1:]
+
+		// Atender la cancelación y reportar el progreso periódicamente
+		if monitor.pulso() {
+			if err := monitor.revisar(avance(actual)); err != nil {
+				terminar(actual, []Estado{})
+				return resultado, err
+			}
+		}
+
+		// Verificar si alcanzamos el estado objetivo
+		if EsObjetivo(actual.Tablero, objetivo) {
+			terminar(actual, reconstruirCamino(actual))
+			return resultado, nil
+		}
+		estadisticas.NodosExpandidos++
+
+		// Generar y evaluar todos los sucesores del estado actual
+		for _, movimiento := range GenerarMovimientos(actual.Tablero) {
+			estadisticas.NodosGenerados++
+			// Si no está visitado, marcarlo y agregarlo a la cola
+			if !visitados.Agregar(movimiento.Tablero) {
+				estadisticas.DuplicadosPodados++
+				continue
+			}
+			sucesor := movimiento
+			sucesor.Padre = actual
+			sucesor.Costo = actual.Costo + 1
+			cola = append(cola, &sucesor)
+		}
+		estadisticas.MaxFrontera = max(estadisticas.MaxFrontera, len(cola))
+	}
+
+	terminar(actual, []Estado{}) // Camino vacío: no hay solución
+	return resultado, nil
+}
+
+func reconstruirCamino(estado *Estado) []Estado {
+	// reconstruirCamino recorre los punteros Padre desde el estado final hasta el inicial
+	// y retorna el camino en orden, comenzando por el estado inicial.
+	camino := []Estado{}
+	for estado != nil {
+		camino = append(camino, *estado)
+		estado = estado.Padre
+	}
+	for i, j := 0, len(camino)-1; i < j; i, j = i+1, j-1 {
+		camino[i], camino[j] = camino[j], camino[i]
+	}
+	return camino
+}
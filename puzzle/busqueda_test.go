@@ -0,0 +1,95 @@
+package puzzle
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBusquedasOptimas(t *testing.T) {
+	// A* e IDA* con heurísticas admisibles, y BFS, encuentran caminos válidos cuya longitud es la
+	// distancia exacta de la tabla de distancias en tableros de toda la gama de profundidades.
+	objetivo := TableroObjetivo(3, 3)
+	tabla, err := ConstruirTablaDistancias(objetivo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	casos := []struct {
+		nombre string
+		buscar func(inicial Tablero) (Resultado, error)
+	}{
+		{"A* Manhattan", func(inicial Tablero) (Resultado, error) {
+			return BusquedaAEstrella(context.Background(), inicial, objetivo, NuevaHeuristicaManhattan(objetivo), nil)
+		}},
+		{"A* conflicto lineal", func(inicial Tablero) (Resultado, error) {
+			return BusquedaAEstrella(context.Background(), inicial, objetivo, NuevaHeuristicaConflictoLineal(objetivo), nil)
+		}},
+		{"IDA* Manhattan", func(inicial Tablero) (Resultado, error) {
+			return BusquedaIDAEstrella(context.Background(), inicial, objetivo, NuevaHeuristicaManhattan(objetivo), nil)
+		}},
+		{"IDA* conflicto lineal", func(inicial Tablero) (Resultado, error) {
+			return BusquedaIDAEstrella(context.Background(), inicial, objetivo, NuevaHeuristicaConflictoLineal(objetivo), nil)
+		}},
+		{"BFS", func(inicial Tablero) (Resultado, error) {
+			return BusquedaAnchura(context.Background(), inicial, objetivo, nil)
+		}},
+	}
+
+	// Un tablero por profundidad, de 0 al diámetro (31 en el 8-puzzle)
+	rng := NuevoGenerador(1)
+	tableros := []Tablero{}
+	for d := 0; d < len(tabla.Histograma()); d++ {
+		tablero, _ := tabla.Aleatorio(d, rng)
+		tableros = append(tableros, tablero)
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			for _, inicial := range tableros {
+				resultado, err := c.buscar(inicial)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got, want := len(resultado.Camino)-1, tabla.Distancia(inicial); got != want {
+					t.Fatalf("%v: camino de %d movimientos, el óptimo es %d", inicial, got, want)
+				}
+				comprobarCamino(t, resultado.Camino, inicial, objetivo)
+			}
+		})
+	}
+}
+
+func comprobarCamino(t *testing.T, camino []Estado, inicial, objetivo Tablero) {
+	// comprobarCamino verifica que el camino vaya del tablero inicial al objetivo aplicando en
+	// cada paso el movimiento indicado por su acción.
+	t.Helper()
+	if len(camino) == 0 || camino[0].Tablero != inicial || camino[len(camino)-1].Tablero != objetivo {
+		t.Fatalf("el camino no va de %v a %v", inicial, objetivo)
+	}
+	for i := 1; i < len(camino); i++ {
+		valido := false
+		for _, sucesor := range GenerarMovimientos(camino[i-1].Tablero) {
+			valido = valido || (sucesor.Accion == camino[i].Accion && sucesor.Tablero == camino[i].Tablero)
+		}
+		if !valido {
+			t.Fatalf("paso %d: %q no lleva de %v a %v", i, camino[i].Accion, camino[i-1].Tablero, camino[i].Tablero)
+		}
+	}
+}
+
+func TestBusquedaCancelada(t *testing.T) {
+	// Con el contexto ya cancelado las búsquedas retornan ctx.Err() sin camino.
+	objetivo := TableroObjetivo(4, 4)
+	inicial := MezclarUniforme(objetivo, NuevoGenerador(1))
+	ctx, cancelar := context.WithCancel(context.Background())
+	cancelar()
+	heuristica := NuevaHeuristicaManhattan(objetivo)
+	busquedas := map[string]func() (Resultado, error){
+		"A*":   func() (Resultado, error) { return BusquedaAEstrella(ctx, inicial, objetivo, heuristica, nil) },
+		"IDA*": func() (Resultado, error) { return BusquedaIDAEstrella(ctx, inicial, objetivo, heuristica, nil) },
+		"BFS":  func() (Resultado, error) { return BusquedaAnchura(ctx, inicial, objetivo, nil) },
+	}
+	for nombre, buscar := range busquedas {
+		if resultado, err := buscar(); err != context.Canceled || len(resultado.Camino) != 0 {
+			t.Errorf("%s: err = %v, camino de %d estados", nombre, err, len(resultado.Camino))
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package puzzle
+
+// bitsPorCasilla es el ancho de cada valor empaquetado en una Clave (valores 0-24).
+const bitsPorCasilla = 5
+
+// casillasPorPalabra es el número de casillas que caben en cada uint64 de la Clave.
+const casillasPorPalabra = 64 / bitsPorCasilla
+
+// Clave es la codificación compacta de un Tablero: cada casilla ocupa 5 bits empaquetados
+// en dos uint64, de modo que dos tableros del mismo tamaño son iguales si y solo si sus claves
+// son iguales. La última casilla no se almacena porque queda determinada por las demás
+// (el tablero es una permutación), lo que permite representar hasta 5x5 en 120 bits.
+type Clave [2]uint64
+
+func Codificar(tablero Tablero) Clave {
+	// Codificar empaqueta el tablero en una Clave.
+	// La casilla 0 ocupa los 5 bits menos significativos de la primera palabra.
+	//
+	// Complejidad temporal: O(filas x columnas) - procesa cada casilla una vez
+	var clave Clave
+	for i := 0; i < tablero.Tamano()-1; i++ {
+		clave[i/casillasPorPalabra] |= uint64(tablero.celdas[i]) << (bitsPorCasilla * (i % casillasPorPalabra))
+	}
+	return clave
+}
+
+func Decodificar(clave Clave, filas, columnas int) Tablero {
+	// Decodificar reconstruye el Tablero de filas x columnas a partir de su Clave
+	// (operación inversa de Codificar). El valor de la última casilla es el único que falta.
+	tablero := Tablero{filas: filas, columnas: columnas}
+	celdas := filas * columnas
+	suma := celdas * (celdas - 1) / 2 // Suma de todos los valores 0..celdas-1
+	for i := 0; i < celdas-1; i++ {
+		valor := (clave[i/casillasPorPalabra] >> (bitsPorCasilla * (i % casillasPorPalabra))) & (1<<bitsPorCasilla - 1)
+		tablero.celdas[i] = uint8(valor)
+		suma -= int(valor)
+	}
+	tablero.celdas[celdas-1] = uint8(suma)
+	return tablero
+}
+
+// ConjuntoEstados es un conjunto hash de tableros indexado por su Clave.
+// Ofrece inserción y consulta en O(1) promedio y es compartido por todos los algoritmos
+// de búsqueda como lista CERRADA / lista de visitados.
+type ConjuntoEstados struct {
+	claves map[Clave]struct{} // Claves de los tableros pertenecientes al conjunto
+}
+
+func NuevoConjuntoEstados() *ConjuntoEstados {
+	// NuevoConjuntoEstados crea un conjunto vacío listo para usarse.
+	return &ConjuntoEstados{claves: make(map[Clave]struct{})}
+}
+
+func (c *ConjuntoEstados) Agregar(tablero Tablero) bool {
+	// Agregar inserta el tablero en el conjunto.
+	// Retorna: true si el tablero era nuevo, false si ya pertenecía al conjunto.
+	clave := Codificar(tablero)
+	if _, existe := c.claves[clave]; existe {
+		return false
+	}
+	c.claves[clave] = struct{}{}
+	return true
+}
+
+func (c *ConjuntoEstados) Contiene(tablero Tablero) bool {
+	// Contiene verifica en O(1) promedio si el tablero pertenece al conjunto.
+	_, existe := c.claves[Codificar(tablero)]
+	return existe
+}
+
+func (c *ConjuntoEstados) Tamano() int {
+	// Tamano retorna el número de tableros distintos almacenados en el conjunto.
+	return len(c.claves)
+}
@@ -0,0 +1,82 @@
+package puzzle
+
+import "testing"
+
+func permutaciones(filas, columnas int) []Tablero {
+	// permutaciones retorna todos los tableros de filas x columnas, resolubles o no.
+	tableros := []Tablero{}
+	tablero := Tablero{filas: filas, columnas: columnas}
+	usados := make([]bool, filas*columnas)
+	var colocar func(pos int)
+	colocar = func(pos int) {
+		if pos == filas*columnas {
+			tableros = append(tableros, tablero)
+			return
+		}
+		for valor := range usados {
+			if !usados[valor] {
+				usados[valor] = true
+				tablero.celdas[pos] = uint8(valor)
+				colocar(pos + 1)
+				usados[valor] = false
+			}
+		}
+	}
+	colocar(0)
+	return tableros
+}
+
+func TestCodificarDecodificar(t *testing.T) {
+	// Decodificar es la inversa de Codificar en todos los tamaños admitidos, incluida la última
+	// casilla, que no se almacena y se deduce de las demás.
+	casos := []struct {
+		filas, columnas int
+	}{
+		{2, 3}, {3, 2}, {2, 4}, {3, 3}, {3, 4}, {4, 4}, {4, 5}, {5, 5},
+	}
+	rng := NuevoGenerador(1)
+	for _, c := range casos {
+		objetivo := TableroObjetivo(c.filas, c.columnas)
+		tableros := []Tablero{objetivo, ObjetivoVacioInicial(c.filas, c.columnas), ObjetivoEspiral(c.filas, c.columnas)}
+		for i := 0; i < 200; i++ {
+			tableros = append(tableros, MezclarUniforme(objetivo, rng))
+		}
+		for _, tablero := range tableros {
+			if got := Decodificar(Codificar(tablero), c.filas, c.columnas); got != tablero {
+				t.Fatalf("%dx%d: Decodificar(Codificar(%v)) = %v", c.filas, c.columnas, tablero, got)
+			}
+		}
+	}
+}
+
+func TestCodificarInyectiva(t *testing.T) {
+	// Tableros distintos del mismo tamaño tienen claves distintas: se comprueba sobre todas las
+	// permutaciones de 2x3, resolubles o no.
+	claves := map[Clave]Tablero{}
+	for _, tablero := range permutaciones(2, 3) {
+		clave := Codificar(tablero)
+		if otro, existe := claves[clave]; existe {
+			t.Fatalf("%v y %v comparten la clave %v", tablero, otro, clave)
+		}
+		claves[clave] = tablero
+	}
+	if len(claves) != 720 {
+		t.Fatalf("se esperaban 720 claves, hay %d", len(claves))
+	}
+}
+
+func TestConjuntoEstados(t *testing.T) {
+	// Agregar informa si el tablero era nuevo y Contiene lo encuentra después.
+	conjunto := NuevoConjuntoEstados()
+	a, b := TableroObjetivo(3, 3), ObjetivoEspiral(3, 3)
+	if !conjunto.Agregar(a) || conjunto.Agregar(a) {
+		t.Fatal("Agregar debe retornar true solo la primera vez")
+	}
+	if !conjunto.Contiene(a) || conjunto.Contiene(b) {
+		t.Fatal("Contiene no refleja los tableros agregados")
+	}
+	conjunto.Agregar(b)
+	if conjunto.Tamano() != 2 {
+		t.Fatalf("Tamano = %d, se esperaba 2", conjunto.Tamano())
+	}
+}
@@ -0,0 +1,66 @@
+package puzzle
+
+import "container/heap"
+
+// nodoCola envuelve un Estado dentro de la cola de prioridad de A*.
+// Guarda f(n) precalculado y un número de orden para desempatar de forma determinista.
+type nodoCola struct {
+	estado *Estado // Estado almacenado en la lista ABIERTA
+	f      int     // f(n) = g(n) + h(n), clave principal de la cola
+	orden  int     // Orden de inserción, último criterio de desempate (FIFO)
+}
+
+// colaPrioridad implementa heap.Interface como un montículo binario mínimo sobre f(n).
+// Los empates se resuelven primero por menor h(n) (nodo más cercano al objetivo)
+// y luego por orden de inserción, de modo que la búsqueda es reproducible.
+type colaPrioridad struct {
+	nodos    []nodoCola // Montículo binario almacenado en un slice
+	contador int        // Siguiente número de orden a asignar
+}
+
+func (c *colaPrioridad) Len() int {
+	// Len retorna el número de nodos en la cola (requerido por heap.Interface).
+	return len(c.nodos)
+}
+
+func (c *colaPrioridad) Less(i, j int) bool {
+	// Less define el orden del montículo: menor f, luego menor h, luego menor orden de inserción.
+	a, b := c.nodos[i], c.nodos[j]
+	if a.f != b.f {
+		return a.f < b.f
+	}
+	if a.estado.Estimacion != b.estado.Estimacion {
+		return a.estado.Estimacion < b.estado.Estimacion
+	}
+	return a.orden < b.orden
+}
+
+func (c *colaPrioridad) Swap(i, j int) {
+	// Swap intercambia dos nodos del montículo (requerido por heap.Interface).
+	c.nodos[i], c.nodos[j] = c.nodos[j], c.nodos[i]
+}
+
+func (c *colaPrioridad) Push(x any) {
+	// Push agrega un nodo al final del slice; usar insertar en lugar de llamarlo directamente.
+	c.nodos = append(c.nodos, x.(nodoCola))
+}
+
+func (c *colaPrioridad) Pop() any {
+	// Pop retira el último nodo del slice; usar extraer en lugar de llamarlo directamente.
+	ultimo := c.nodos[len(c.nodos)-1]
+	c.nodos = c.nodos[:len(c.nodos)-1]
+	return ultimo
+}
+
+func (c *colaPrioridad) insertar(estado *Estado) {
+	// insertar agrega un estado a la cola con prioridad f(n) = g(n) + h(n).
+	// Complejidad: O(log n)
+	heap.Push(c, nodoCola{estado: estado, f: estado.Costo + estado.Estimacion, orden: c.contador})
+	c.contador++
+}
+
+func (c *colaPrioridad) extraer() *Estado {
+	// extraer retira y retorna el estado con menor f(n) de la cola.
+	// Complejidad: O(log n)
+	return heap.Pop(c).(nodoCola).estado
+}
@@ -0,0 +1,73 @@
+package puzzle
+
+import (
+	"time"
+	"unsafe"
+)
+
+// Resultado es lo que retornan todos los algoritmos de búsqueda: el camino encontrado
+// junto con las estadísticas de la ejecución.
+type Resultado struct {
+	Camino       []Estado     // Secuencia de estados desde el inicial hasta el objetivo (vacío si no hay solución)
+	Estadisticas Estadisticas // Métricas de rendimiento de la búsqueda
+	Iteraciones  int          // Iteraciones de profundización (solo IDA*; 0 en A* y BFS)
+	Cotas        []int        // Cota de f(n) utilizada en cada iteración (solo IDA*)
+}
+
+// Estadisticas reúne las métricas de rendimiento de una búsqueda, comparables entre algoritmos.
+// Las etiquetas JSON permiten exportarlas tal cual desde la línea de comandos y los benchmarks.
+type Estadisticas struct {
+	NodosExpandidos    int           `json:"nodos_expandidos"`    // Nodos cuyos sucesores se generaron
+	NodosGenerados     int           `json:"nodos_generados"`     // Sucesores generados (sin contar el estado inicial)
+	MaxFrontera        int           `json:"max_frontera"`        // Tamaño máximo de la lista ABIERTA, cola FIFO o camino de IDA*
+	MaxCerrada         int           `json:"max_cerrada"`         // Tamaño máximo de la lista CERRADA o de visitados (0 en IDA*)
+	DuplicadosPodados  int           `json:"duplicados_podados"`  // Sucesores descartados por repetir un estado ya conocido
+	FactorRamificacion float64       `json:"factor_ramificacion"` // Factor de ramificación efectivo b* (0 si la solución tiene 0 pasos)
+	MemoriaEstimada    int64         `json:"memoria_estimada"`    // Estimación del pico de memoria de las estructuras de búsqueda, en bytes
+	Tiempo             time.Duration `json:"tiempo_ns"`           // Tiempo de reloj de la búsqueda
+}
+
+// Tamaños aproximados usados para estimar la memoria de cada algoritmo.
+const (
+	bytesEstado         = int64(unsafe.Sizeof(Estado{}))       // Nodo del árbol de búsqueda
+	bytesNodoCola       = int64(unsafe.Sizeof(nodoCola{}))     // Entrada del montículo de A*
+	bytesPuntero        = int64(unsafe.Sizeof((*Estado)(nil))) // Entrada de la cola FIFO de BFS
+	bytesEntradaCerrada = int64(unsafe.Sizeof(Clave{})) + 8    // Clave más la sobrecarga promedio del mapa
+	bytesMarcoIDA       = int64(unsafe.Sizeof(int(0))) + 128   // Acción guardada más el marco de la recursión
+)
+
+func factorRamificacionEfectivo(generados, profundidad int) float64 {
+	// factorRamificacionEfectivo calcula b* tal que un árbol uniforme de profundidad d
+	// contiene los N nodos generados: N = b* + b*² + ... + b*^d (Russell & Norvig).
+	// Se resuelve por bisección, ya que la suma es creciente en b*.
+	if profundidad == 0 || generados == 0 {
+		return 0
+	}
+	suma := func(b float64) float64 {
+		total, termino := 0.0, 1.0
+		for i := 0; i < profundidad; i++ {
+			termino *= b
+			total += termino
+		}
+		return total
+	}
+	bajo, alto := 0.0, float64(generados) // suma(alto) >= N para cualquier d >= 1
+	for i := 0; i < 100; i++ {
+		medio := (bajo + alto) / 2
+		if suma(medio) < float64(generados) {
+			bajo = medio
+		} else {
+			alto = medio
+		}
+	}
+	return (bajo + alto) / 2
+}
+
+func (e *Estadisticas) completar(camino []Estado, inicio time.Time) {
+	// completar calcula las métricas derivadas al terminar una búsqueda: el factor de
+	// ramificación efectivo a partir de la longitud de la solución y el tiempo transcurrido.
+	if len(camino) > 0 {
+		e.FactorRamificacion = factorRamificacionEfectivo(e.NodosGenerados, len(camino)-1)
+	}
+	e.Tiempo = time.Since(inicio)
+}
@@ -0,0 +1,62 @@
+package puzzle
+
+import "math/rand/v2"
+
+func NuevoGenerador(semilla uint64) *rand.Rand {
+	// NuevoGenerador crea el generador aleatorio que reciben todas las funciones de mezcla del
+	// paquete. Es determinista: la misma semilla produce siempre la misma secuencia y, por tanto,
+	// los mismos tableros, lo que permite repetir una mezcla en un reporte de error o un ejercicio.
+	return rand.New(rand.NewPCG(semilla, semilla))
+}
+
+func MezclarAleatorio(objetivo Tablero, movimientos int, rng *rand.Rand) Tablero {
+	// MezclarAleatorio genera una configuración aleatoria aplicando movimientos válidos al azar
+	// a partir del objetivo. El resultado siempre es resoluble, ya que cada movimiento es reversible.
+	//
+	// PARÁMETROS:
+	// - objetivo: configuración de partida (y objetivo de la búsqueda posterior)
+	// - movimientos: número de movimientos aleatorios a aplicar, por ejemplo 150
+	// - rng: generador aleatorio (ver NuevoGenerador); la misma semilla produce el mismo tablero
+	//
+	// RETORNA: el tablero mezclado
+	tablero := objetivo
+	for i := 0; i < movimientos; i++ {
+		// Seleccionar un movimiento aleatorio de los disponibles
+		sucesores := GenerarMovimientos(tablero)
+		tablero = sucesores[rng.IntN(len(sucesores))].Tablero
+	}
+	return tablero
+}
+
+func MezclarUniforme(objetivo Tablero, rng *rand.Rand) Tablero {
+	// MezclarUniforme genera una configuración elegida uniformemente entre todas las resolubles,
+	// sin el sesgo de una caminata aleatoria hacia los tableros cercanos al objetivo:
+	//
+	// 1. Permuta al azar todas las casillas del objetivo (Fisher-Yates)
+	// 2. Si la permutación no es resoluble, intercambia dos fichas distintas del vacío
+	//
+	// Intercambiar dos fichas invierte la paridad y empareja cada tablero irresoluble con uno
+	// resoluble distinto, por lo que todos los resolubles resultan igualmente probables.
+	//
+	// PARÁMETROS:
+	// - objetivo: configuración objetivo (define las dimensiones y la resolubilidad)
+	// - rng: generador aleatorio (ver NuevoGenerador); la misma semilla produce el mismo tablero
+	//
+	// RETORNA: el tablero mezclado
+	tablero := objetivo
+	rng.Shuffle(tablero.Tamano(), func(i, j int) {
+		tablero.celdas[i], tablero.celdas[j] = tablero.celdas[j], tablero.celdas[i]
+	})
+	if EsResoluble(tablero, objetivo) {
+		return tablero
+	}
+
+	// Las dos primeras casillas con ficha (el vacío ocupa a lo sumo una de las tres primeras)
+	i, j := 0, 1
+	if tablero.celdas[i] == 0 {
+		i = 2
+	} else if tablero.celdas[j] == 0 {
+		j = 2
+	}
+	return tablero.Intercambiar(i, j)
+}
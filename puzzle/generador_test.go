@@ -0,0 +1,60 @@
+package puzzle
+
+import "testing"
+
+func TestMezclarResoluble(t *testing.T) {
+	// Las dos mezclas producen siempre tableros resolubles, también con columnas pares, donde
+	// la fila del vacío interviene en la paridad, y con objetivos distintos del estándar.
+	objetivos := []Tablero{
+		TableroObjetivo(2, 3), TableroObjetivo(2, 4), TableroObjetivo(3, 3), TableroObjetivo(4, 4),
+		ObjetivoVacioInicial(2, 4), ObjetivoVacioInicial(4, 4), ObjetivoEspiral(3, 4), ObjetivoEspiral(5, 5),
+	}
+	rng := NuevoGenerador(1)
+	for _, objetivo := range objetivos {
+		for i := 0; i < 500; i++ {
+			if tablero := MezclarUniforme(objetivo, rng); !EsResoluble(tablero, objetivo) {
+				t.Fatalf("MezclarUniforme produjo %v, irresoluble para %v", tablero, objetivo)
+			}
+			if tablero := MezclarAleatorio(objetivo, 50, rng); !EsResoluble(tablero, objetivo) {
+				t.Fatalf("MezclarAleatorio produjo %v, irresoluble para %v", tablero, objetivo)
+			}
+		}
+	}
+}
+
+func TestMezclarUniformeDistribucion(t *testing.T) {
+	// Todos los tableros resolubles de 2x3 aparecen con frecuencias similares, incluidos los que
+	// provienen de corregir la paridad con el vacío en cualquiera de las tres primeras casillas.
+	objetivos := []Tablero{TableroObjetivo(2, 3), ObjetivoVacioInicial(2, 3)}
+	for _, objetivo := range objetivos {
+		const muestras = 360 * 200
+		frecuencias := map[Tablero]int{}
+		rng := NuevoGenerador(2)
+		for i := 0; i < muestras; i++ {
+			frecuencias[MezclarUniforme(objetivo, rng)]++
+		}
+		if len(frecuencias) != 360 {
+			t.Fatalf("objetivo %v: aparecen %d tableros de 360", objetivo, len(frecuencias))
+		}
+		for tablero, n := range frecuencias {
+			if n < 120 || n > 280 { // Esperado 200, desviación típica ~14
+				t.Fatalf("objetivo %v: %v aparece %d veces, se esperaban unas 200", objetivo, tablero, n)
+			}
+		}
+	}
+}
+
+func TestMezclarDeterminista(t *testing.T) {
+	// La misma semilla produce los mismos tableros y semillas distintas, en general, otros.
+	objetivo := TableroObjetivo(4, 4)
+	mezclar := func(semilla uint64) [2]Tablero {
+		rng := NuevoGenerador(semilla)
+		return [2]Tablero{MezclarAleatorio(objetivo, 100, rng), MezclarUniforme(objetivo, rng)}
+	}
+	if mezclar(0) != mezclar(0) || mezclar(42) != mezclar(42) {
+		t.Fatal("la misma semilla produjo tableros distintos")
+	}
+	if mezclar(0) == mezclar(42) {
+		t.Fatal("semillas distintas produjeron los mismos tableros")
+	}
+}
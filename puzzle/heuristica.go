@@ -0,0 +1,151 @@
+package puzzle
+
+// Heuristica es una función h(n) que estima el número de movimientos restantes hasta el objetivo.
+// Los algoritmos informados (como BusquedaIDAEstrella) reciben la heurística como parámetro,
+// de modo que cualquier función con esta firma puede conectarse sin modificar la búsqueda.
+// Para garantizar soluciones óptimas la heurística debe ser admisible y haberse construido
+// para el mismo objetivo que se pasa a la búsqueda.
+type Heuristica func(tablero Tablero) int
+
+// posicionObjetivo guarda las coordenadas de una ficha en el tablero objetivo.
+type posicionObjetivo struct {
+	fila, col int
+}
+
+// tablaObjetivo es la tabla de búsqueda ficha -> posición objetivo, precalculada una sola vez
+// por objetivo para que las heurísticas no dependan de la disposición estándar.
+type tablaObjetivo [MaxCeldas]posicionObjetivo
+
+func nuevaTablaObjetivo(objetivo Tablero) *tablaObjetivo {
+	// nuevaTablaObjetivo recorre el objetivo y registra la fila y columna de cada ficha.
+	tabla := &tablaObjetivo{}
+	for i := 0; i < objetivo.Tamano(); i++ {
+		tabla[objetivo.celdas[i]] = posicionObjetivo{fila: i / objetivo.columnas, col: i % objetivo.columnas}
+	}
+	return tabla
+}
+
+func NuevaHeuristicaManhattan(objetivo Tablero) Heuristica {
+	// NuevaHeuristicaManhattan construye la heurística de distancia Manhattan hacia el objetivo dado.
+	// La distancia Manhattan es la suma de distancias horizontales y verticales
+	// de cada ficha desde su posición actual hasta su posición objetivo.
+	// Esta heurística es admisible (nunca sobreestima) y consistente (monótona).
+	//
+	// Las posiciones objetivo de cada ficha se precalculan una sola vez, por lo que la heurística
+	// es correcta para cualquier objetivo (vacío al inicio, espiral, personalizado...).
+	//
+	// Complejidad temporal: O(filas x columnas) por evaluación - evalúa cada casilla una vez
+	// Complejidad espacial: O(filas x columnas) - tabla de posiciones objetivo
+	//
+	// Retorna: función que suma las distancias Manhattan de todas las fichas mal ubicadas
+	destinos := nuevaTablaObjetivo(objetivo)
+	return func(tablero Tablero) int {
+		distancia := 0
+		columnas := tablero.columnas
+		for i := 0; i < tablero.Tamano(); i++ {
+			if valor := tablero.celdas[i]; valor != 0 {
+				// Posición actual y posición objetivo en coordenadas (fila, columna)
+				filaActual := i / columnas
+				colActual := i % columnas
+				destino := destinos[valor]
+
+				// Sumar distancia Manhattan: |x1-x2| + |y1-y2|
+				distancia += abs(filaActual-destino.fila) + abs(colActual-destino.col)
+			}
+		}
+		return distancia
+	}
+}
+
+func abs(x int) int {
+	// abs retorna el valor absoluto de un entero.
+	// Función auxiliar para cálculos de distancia.
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func NuevaHeuristicaConflictoLineal(objetivo Tablero) Heuristica {
+	// NuevaHeuristicaConflictoLineal combina la distancia Manhattan con la penalización por conflictos lineales.
+	// Dos fichas están en conflicto lineal si ambas se encuentran en su fila (o columna) objetivo
+	// pero en orden invertido: una de ellas debe salir de la línea y volver, lo que cuesta al menos
+	// 2 movimientos adicionales que la distancia Manhattan no contabiliza.
+	// Esta heurística sigue siendo admisible y domina a la distancia Manhattan.
+	//
+	// Complejidad temporal: O(F·C·(F+C)) - evalúa F filas y C columnas con comparaciones cuadráticas por línea
+	// Complejidad espacial: O(F+C) - usa un arreglo auxiliar por línea
+	//
+	// Retorna: función que calcula Manhattan + 2 x (fichas que deben salir de su línea)
+	manhattan := NuevaHeuristicaManhattan(objetivo)
+	destinos := nuevaTablaObjetivo(objetivo)
+	return func(tablero Tablero) int {
+		return manhattan(tablero) + 2*conflictosLineales(tablero, destinos)
+	}
+}
+
+func conflictosLineales(tablero Tablero, posiciones *tablaObjetivo) int {
+	// conflictosLineales cuenta, para cada fila y columna, el mínimo número de fichas que deben
+	// abandonar la línea para que las restantes queden en el orden correcto.
+	conflictos := 0
+	filas, columnas := tablero.filas, tablero.columnas
+
+	// destinos[k]: posición objetivo dentro de la línea de la ficha ubicada en la casilla k,
+	// o -1 si la casilla está vacía o su ficha no pertenece a esta línea
+	var destinos [DimensionMaxima]int
+
+	// Conflictos en cada fila (líneas de longitud columnas)
+	for fila := 0; fila < filas; fila++ {
+		for k := 0; k < columnas; k++ {
+			destinos[k] = -1
+			if valor := tablero.celdas[fila*columnas+k]; valor != 0 && posiciones[valor].fila == fila {
+				destinos[k] = posiciones[valor].col
+			}
+		}
+		conflictos += conflictosEnLinea(destinos[:columnas])
+	}
+
+	// Conflictos en cada columna (líneas de longitud filas)
+	for col := 0; col < columnas; col++ {
+		for k := 0; k < filas; k++ {
+			destinos[k] = -1
+			if valor := tablero.celdas[k*columnas+col]; valor != 0 && posiciones[valor].col == col {
+				destinos[k] = posiciones[valor].fila
+			}
+		}
+		conflictos += conflictosEnLinea(destinos[:filas])
+	}
+	return conflictos
+}
+
+func conflictosEnLinea(destinos []int) int {
+	// conflictosEnLinea elimina repetidamente la ficha involucrada en más conflictos hasta que
+	// no quede ninguno. Las fichas j < k están en conflicto si destinos[j] > destinos[k].
+	// Retorna: número de fichas eliminadas (cada una aporta 2 movimientos a la heurística).
+	eliminadas := 0
+	for {
+		peor, maxConflictos := -1, 0
+		for j := range destinos {
+			if destinos[j] < 0 {
+				continue
+			}
+			cuenta := 0
+			for k := range destinos {
+				if k == j || destinos[k] < 0 {
+					continue
+				}
+				if (k < j && destinos[k] > destinos[j]) || (k > j && destinos[k] < destinos[j]) {
+					cuenta++
+				}
+			}
+			if cuenta > maxConflictos {
+				peor, maxConflictos = j, cuenta
+			}
+		}
+		if peor < 0 {
+			return eliminadas
+		}
+		destinos[peor] = -1
+		eliminadas++
+	}
+}
@@ -0,0 +1,52 @@
+package puzzle
+
+import "testing"
+
+func TestHeuristicasAdmisibles(t *testing.T) {
+	// Ninguna heurística sobreestima la distancia óptima en ningún estado resoluble, valen 0 en el
+	// objetivo y el conflicto lineal nunca es menor que Manhattan.
+	casos := []struct {
+		nombre   string
+		objetivo Tablero
+	}{
+		{"3x3 estándar", TableroObjetivo(3, 3)},
+		{"3x3 vacío al inicio", ObjetivoVacioInicial(3, 3)},
+		{"3x3 espiral", ObjetivoEspiral(3, 3)},
+		{"2x4 estándar", TableroObjetivo(2, 4)},
+		{"4x2 espiral", ObjetivoEspiral(4, 2)},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			tabla, err := ConstruirTablaDistancias(c.objetivo, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			manhattan := NuevaHeuristicaManhattan(c.objetivo)
+			conflicto := NuevaHeuristicaConflictoLineal(c.objetivo)
+			base := ConstruirBasePatrones(c.objetivo, GruposPredeterminados(c.objetivo.Filas(), c.objetivo.Columnas()), nil)
+			heuristicas := []struct {
+				nombre  string
+				evaluar Heuristica
+			}{
+				{"Manhattan", manhattan},
+				{"conflicto lineal", conflicto},
+				{"base de patrones", base.Evaluar},
+			}
+			for _, h := range heuristicas {
+				if v := h.evaluar(c.objetivo); v != 0 {
+					t.Fatalf("%s vale %d en el objetivo", h.nombre, v)
+				}
+			}
+			tabla.Recorrer(func(tablero Tablero, distancia int) {
+				for _, h := range heuristicas {
+					if v := h.evaluar(tablero); v > distancia {
+						t.Fatalf("%v: %s = %d sobreestima la distancia %d", tablero, h.nombre, v, distancia)
+					}
+				}
+				if conflicto(tablero) < manhattan(tablero) {
+					t.Fatalf("%v: conflicto lineal %d menor que Manhattan %d", tablero, conflicto(tablero), manhattan(tablero))
+				}
+			})
+		})
+	}
+}
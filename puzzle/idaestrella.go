@@ -0,0 +1,194 @@
+package puzzle
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// buscadorIDA mantiene el estado mutable de la búsqueda en profundidad acotada de IDA*.
+// El tablero se modifica en el lugar y se restaura al retroceder, por lo que la memoria
+// utilizada es proporcional a la profundidad de la solución y no al número de nodos.
+type buscadorIDA struct {
+	monitor    *monitor   // Cancelación y reporte de progreso periódicos
+	err        error      // Error del contexto si la búsqueda fue cancelada
+	objetivo   Tablero    // Configuración objetivo
+	heuristica Heuristica // h(n) utilizada para podar ramas con f(n) > cota
+	tablero    Tablero    // Tablero del nodo actual (se modifica en el lugar)
+	acciones   []int      // Direcciones aplicadas desde el estado inicial hasta el nodo actual
+	cota       int        // Cota de f(n) de la iteración en curso
+	generados  int        // Nodos generados en todas las iteraciones
+	expandidos int        // Nodos expandidos (con f(n) dentro de la cota) en todas las iteraciones
+	podados    int        // Movimientos descartados por deshacer el movimiento anterior
+	maxCamino  int        // Longitud máxima alcanzada por el camino actual
+}
+
+func BusquedaIDAEstrella(ctx context.Context, inicial Tablero, objetivo Tablero, heuristica Heuristica, progreso func(Progreso)) (Resultado, error) {
+	// BusquedaIDAEstrella implementa IDA* (A* de profundización iterativa) para encontrar la solución óptima.
+	//
+	// ALGORITMO IDA*:
+	// 1. Inicia la cota con h(inicial)
+	// 2. Realiza una búsqueda en profundidad que poda todo nodo con f(n) = g(n) + h(n) > cota
+	// 3. Si encuentra el objetivo, reconstruye y retorna el camino
+	// 4. Si no, la nueva cota es el menor f(n) que excedió la cota anterior
+	// 5. Repite hasta encontrar solución o agotar posibilidades
+	//
+	// PROPIEDADES:
+	// - Completitud: Siempre encuentra solución si existe
+	// - Optimalidad: Garantiza la solución de menor costo con heurística admisible
+	// - Complejidad temporal: O(b^d), reexpandiendo los niveles superiores en cada iteración
+	// - Complejidad espacial: O(d), no mantiene lista ABIERTA ni CERRADA
+	//
+	// DIFERENCIAS CON A*:
+	// - Memoria lineal en la profundidad: apto para el 15-puzzle, donde A* agota la memoria
+	// - Solo evita deshacer el último movimiento; puede reexpandir estados por caminos distintos
+	//
+	// PARÁMETROS:
+	// - ctx: contexto de cancelación; la búsqueda se detiene poco después de cancelarse
+	// - inicial: configuración inicial del tablero (Tablero)
+	// - objetivo: configuración objetivo del tablero (Tablero)
+	// - heuristica: función heurística admisible, por ejemplo NuevaHeuristicaManhattan(objetivo)
+	// - progreso: función opcional (puede ser nil) que recibe el avance cada IntervaloProgreso;
+	//   la frontera reportada es la longitud del camino actual y MejorF la cota de la iteración
+	//
+	// RETORNA: Resultado con el camino solución, las estadísticas y las cotas de cada iteración,
+	// y el error del contexto si la búsqueda fue cancelada (las métricas reflejan el trabajo realizado).
+	// Si el tablero no es resoluble se retorna sin iterar (el camino queda vacío).
+	// La frontera de IDA* es el camino actual y no mantiene lista CERRADA (MaxCerrada es 0); los
+	// duplicados podados son los movimientos que deshacen el anterior.
+	inicio := time.Now()
+	resultado := Resultado{Camino: []Estado{}}
+
+	// Verificar la paridad antes de buscar: en un tablero irresoluble la cota crecería sin límite
+	if !EsResoluble(inicial, objetivo) {
+		resultado.Estadisticas.completar(resultado.Camino, inicio)
+		return resultado, nil
+	}
+
+	buscador := &buscadorIDA{
+		monitor:    nuevoMonitor(ctx, progreso),
+		objetivo:   objetivo,
+		heuristica: heuristica,
+		tablero:    inicial,
+	}
+
+	cota := heuristica(inicial)
+	vacio := EncontrarVacio(inicial)
+	for {
+		resultado.Iteraciones++
+		resultado.Cotas = append(resultado.Cotas, cota)
+
+		buscador.cota = cota
+		encontrado, siguiente := buscador.buscar(vacio, 0, cota, -1)
+		if buscador.err != nil || encontrado || siguiente == math.MaxInt {
+			// Búsqueda terminada: cancelada, resuelta o con el espacio alcanzable agotado
+			// (ningún nodo excedió la cota)
+			buscador.monitor.finalizar(buscador.avance(len(buscador.acciones)))
+			if encontrado {
+				resultado.Camino = caminoDesdeAcciones(inicial, buscador.acciones)
+			}
+			buscador.registrar(&resultado.Estadisticas)
+			resultado.Estadisticas.completar(resultado.Camino, inicio)
+			return resultado, buscador.err
+		}
+		cota = siguiente
+	}
+}
+
+func (b *buscadorIDA) registrar(estadisticas *Estadisticas) {
+	// registrar vuelca los contadores acumulados en todas las iteraciones en las estadísticas.
+	// La memoria se limita al camino actual: una acción y un marco de recursión por nivel.
+	estadisticas.NodosExpandidos = b.expandidos
+	estadisticas.NodosGenerados = b.generados
+	estadisticas.DuplicadosPodados = b.podados
+	estadisticas.MaxFrontera = b.maxCamino
+	estadisticas.MemoriaEstimada = int64(b.maxCamino+1) * bytesMarcoIDA
+}
+
+func (b *buscadorIDA) avance(g int) Progreso {
+	// avance describe el estado de la búsqueda para la función de progreso.
+	return Progreso{
+		Expandidos:  b.expandidos,
+		Frontera:    len(b.acciones),
+		MejorF:      b.cota,
+		Profundidad: g,
+	}
+}
+
+func (b *buscadorIDA) buscar(vacio, g, cota, previa int) (bool, int) {
+	// buscar realiza la búsqueda en profundidad acotada desde el nodo actual.
+	// Parámetro previa: dirección que generó el nodo actual (-1 en la raíz), para no deshacerla.
+	// Retorna: si se encontró el objetivo y, en caso contrario, el menor f(n) que excedió la cota.
+	// Si el contexto se cancela, registra el error en b.err y retrocede sin seguir explorando.
+	if b.monitor.pulso() {
+		if b.err = b.monitor.revisar(b.avance(g)); b.err != nil {
+			return false, math.MaxInt
+		}
+	}
+	f := g + b.heuristica(b.tablero)
+	if f > cota {
+		return false, f
+	}
+	if EsObjetivo(b.tablero, b.objetivo) {
+		return true, f
+	}
+	b.expandidos++
+
+	minimo := math.MaxInt
+	filas, columnas := b.tablero.filas, b.tablero.columnas
+	celdas := &b.tablero.celdas
+	fila, col := vacio/columnas, vacio%columnas
+	for i, d := range direcciones {
+		// No deshacer el movimiento anterior (el opuesto de la dirección i es i^1); siempre es
+		// un movimiento válido, por lo que cuenta como duplicado podado
+		if previa >= 0 && i == previa^1 {
+			b.podados++
+			continue
+		}
+		nuevaFila, nuevaCol := fila+d.df, col+d.dc
+		if nuevaFila < 0 || nuevaFila >= filas || nuevaCol < 0 || nuevaCol >= columnas {
+			continue
+		}
+		nuevoVacio := nuevaFila*columnas + nuevaCol
+
+		// Aplicar el movimiento en el lugar
+		celdas[vacio], celdas[nuevoVacio] = celdas[nuevoVacio], celdas[vacio]
+		b.acciones = append(b.acciones, i)
+		b.generados++
+		b.maxCamino = max(b.maxCamino, len(b.acciones))
+
+		encontrado, t := b.buscar(nuevoVacio, g+1, cota, i)
+		if encontrado {
+			return true, t
+		}
+		if b.err != nil {
+			return false, math.MaxInt
+		}
+		if t < minimo {
+			minimo = t
+		}
+
+		// Deshacer el movimiento al retroceder
+		b.acciones = b.acciones[:len(b.acciones)-1]
+		celdas[vacio], celdas[nuevoVacio] = celdas[nuevoVacio], celdas[vacio]
+	}
+	return false, minimo
+}
+
+func caminoDesdeAcciones(inicial Tablero, acciones []int) []Estado {
+	// caminoDesdeAcciones reproduce la secuencia de direcciones desde el tablero inicial
+	// y construye el camino de Estados enlazados, igual al que retornan A* y BFS.
+	estado := &Estado{Tablero: inicial}
+	vacio := EncontrarVacio(inicial)
+	for _, i := range acciones {
+		d := direcciones[i]
+		columnas := inicial.columnas
+		nuevoVacio := (vacio/columnas+d.df)*columnas + vacio%columnas + d.dc
+		siguiente := &Estado{Tablero: estado.Tablero, Padre: estado, Costo: estado.Costo + 1, Accion: d.accion}
+		celdas := &siguiente.Tablero.celdas
+		celdas[vacio], celdas[nuevoVacio] = celdas[nuevoVacio], celdas[vacio]
+		estado = siguiente
+		vacio = nuevoVacio
+	}
+	return reconstruirCamino(estado)
+}
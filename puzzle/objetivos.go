@@ -0,0 +1,54 @@
+package puzzle
+
+func ObjetivoVacioInicial(filas, columnas int) Tablero {
+	// ObjetivoVacioInicial retorna el objetivo con el espacio vacío en la primera casilla
+	// y las fichas 1..filas*columnas-1 a continuación en orden de filas.
+	tablero := Tablero{filas: filas, columnas: columnas}
+	for i := 1; i < filas*columnas; i++ {
+		tablero.celdas[i] = uint8(i)
+	}
+	return tablero
+}
+
+func ObjetivoEspiral(filas, columnas int) Tablero {
+	// ObjetivoEspiral retorna el objetivo "caracol": las fichas 1, 2, 3... recorren el borde del
+	// tablero en sentido horario desde la esquina superior izquierda, avanzando hacia el interior,
+	// y el espacio vacío ocupa la última casilla de la espiral.
+	//
+	// Ejemplo 3x3:
+	//   1 2 3
+	//   8 0 4
+	//   7 6 5
+	tablero := Tablero{filas: filas, columnas: columnas}
+	arriba, abajo, izquierda, derecha := 0, filas-1, 0, columnas-1
+	valor, celdas := 1, filas*columnas
+
+	// ubicar coloca la siguiente ficha; la última casilla de la espiral queda con 0 (vacío)
+	ubicar := func(fila, col int) {
+		if valor < celdas {
+			tablero.celdas[fila*columnas+col] = uint8(valor)
+		}
+		valor++
+	}
+
+	for arriba <= abajo && izquierda <= derecha {
+		for col := izquierda; col <= derecha; col++ {
+			ubicar(arriba, col)
+		}
+		for fila := arriba + 1; fila <= abajo; fila++ {
+			ubicar(fila, derecha)
+		}
+		if arriba < abajo {
+			for col := derecha - 1; col >= izquierda; col-- {
+				ubicar(abajo, col)
+			}
+		}
+		if izquierda < derecha {
+			for fila := abajo - 1; fila > arriba; fila-- {
+				ubicar(fila, izquierda)
+			}
+		}
+		arriba, abajo, izquierda, derecha = arriba+1, abajo-1, izquierda+1, derecha-1
+	}
+	return tablero
+}
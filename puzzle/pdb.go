@@ -0,0 +1,514 @@
+package puzzle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// firmaBasePatrones identifica los archivos binarios generados por BasePatrones.Guardar.
+// La versión 3 incluye el vacío en la abstracción; las cachés anteriores se reconstruyen.
+var firmaBasePatrones = [4]byte{'P', 'D', 'B', '3'}
+
+// distanciaDesconocida marca las entradas de la tabla que la BFS aún no ha alcanzado.
+const distanciaDesconocida = 0xFF
+
+// BasePatrones es una base de datos de patrones (PDB) aditiva y disjunta.
+// Las fichas se dividen en grupos disjuntos; para cada grupo se precalcula, mediante una BFS
+// retrógrada desde el objetivo (estándar o personalizado), el mínimo número de movimientos de las fichas del grupo
+// necesarios para llevarlas a su posición objetivo, teniendo en cuenta que solo se mueven
+// deslizándose al vacío. Como cada movimiento desplaza exactamente una ficha y solo se cuentan
+// los de las fichas del grupo, la suma de los valores de todos los grupos es una heurística admisible.
+type BasePatrones struct {
+	filas    int      // Número de filas del tablero
+	columnas int      // Número de columnas del tablero
+	objetivo Tablero  // Configuración objetivo para la que se construyó la base
+	patrones []patron // Un patrón por cada grupo disjunto de fichas
+}
+
+// patron contiene la tabla de distancias de un grupo de fichas.
+type patron struct {
+	fichas []int   // Fichas que forman el grupo (sin el vacío)
+	pesos  []int   // Factores para calcular el rango de una disposición parcial
+	tabla  []uint8 // Distancia mínima indexada por el rango de las posiciones de las fichas
+}
+
+func GruposPredeterminados(filas, columnas int) [][]int {
+	// GruposPredeterminados retorna la partición de fichas utilizada por defecto para cada tamaño:
+	// - 3x3: 4-4 (tabla de 3.024 entradas por grupo)
+	// - 4x4: 6-6-3 de Korf y Felner (tabla de 5.765.760 entradas para los grupos de 6 fichas)
+	// - 5x5: seis grupos de 4 fichas (tabla de 303.600 entradas por grupo)
+	// - Otros tamaños: grupos de fichas consecutivas, de 6 fichas hasta 16 casillas y de 4 en adelante
+	switch {
+	case filas == 3 && columnas == 3:
+		return [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}}
+	case filas == 4 && columnas == 4:
+		return [][]int{{1, 5, 6, 9, 10, 13}, {7, 8, 11, 12, 14, 15}, {2, 3, 4}}
+	case filas == 5 && columnas == 5:
+		return [][]int{{1, 2, 6, 7}, {3, 4, 5, 8}, {9, 10, 14, 15}, {11, 12, 16, 17}, {13, 18, 19, 20}, {21, 22, 23, 24}}
+	}
+
+	celdas := filas * columnas
+	porGrupo := 6
+	if celdas > 16 {
+		porGrupo = 4 // Mantiene cada tabla por debajo de 20x19x18x17 = 116.280 entradas
+	}
+	grupos := [][]int{}
+	for inicio := 1; inicio < celdas; inicio += porGrupo {
+		grupo := []int{}
+		for ficha := inicio; ficha < inicio+porGrupo && ficha < celdas; ficha++ {
+			grupo = append(grupo, ficha)
+		}
+		grupos = append(grupos, grupo)
+	}
+	return grupos
+}
+
+func ConstruirBasePatrones(objetivo Tablero, grupos [][]int, progreso func(float64)) *BasePatrones {
+	// ConstruirBasePatrones genera la base de patrones para los grupos de fichas indicados.
+	//
+	// CONSTRUCCIÓN:
+	// 1. Cada grupo se abstrae como las posiciones de sus fichas más la del vacío; las demás
+	//    fichas son indistinguibles entre sí
+	// 2. Se realiza una BFS 0-1 retrógrada desde el objetivo: deslizar una ficha del grupo al
+	//    vacío cuesta 1 y deslizar cualquier otra ficha cuesta 0
+	// 3. La distancia de cada disposición del grupo es el mínimo sobre todas las posiciones del
+	//    vacío, y se guarda en una tabla de bytes indexada por rango
+	//
+	// PARÁMETROS:
+	// - objetivo: configuración objetivo; determina las dimensiones y la posición final de cada ficha
+	// - grupos: particiones disjuntas de las fichas 1..filas*columnas-1, por ejemplo GruposPredeterminados(filas, columnas)
+	// - progreso: función opcional (puede ser nil) que recibe el avance entre 0 y 1; los grupos se
+	//   construyen en paralelo, pero las llamadas a progreso nunca son simultáneas
+	//
+	// RETORNA: la base de patrones lista para usarse con el método Evaluar
+	base := &BasePatrones{filas: objetivo.filas, columnas: objetivo.columnas, objetivo: objetivo}
+
+	// El progreso se mide sobre el total de estados (grupo más vacío) de todos los grupos
+	total, procesadas := 0, 0
+	for _, fichas := range grupos {
+		total += variaciones(base.filas*base.columnas, len(fichas)+1)
+	}
+	var mutex sync.Mutex
+	reportar := func(n int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		procesadas += n
+		if progreso != nil {
+			progreso(float64(procesadas) / float64(total))
+		}
+	}
+
+	// Los grupos son independientes: cada uno se construye en su propia goroutine
+	base.patrones = make([]patron, len(grupos))
+	var grupo sync.WaitGroup
+	for i, fichas := range grupos {
+		grupo.Add(1)
+		go func() {
+			defer grupo.Done()
+			base.patrones[i] = base.construirPatron(fichas, reportar)
+		}()
+	}
+	grupo.Wait()
+	if progreso != nil {
+		progreso(1)
+	}
+	return base
+}
+
+func (b *BasePatrones) construirPatron(fichas []int, reportar func(int)) patron {
+	// construirPatron ejecuta la BFS 0-1 retrógrada de un grupo de fichas sobre el espacio
+	// extendido (posiciones del grupo y del vacío) y proyecta cada distancia a la tabla del grupo.
+	// Parámetro reportar: recibe periódicamente el número de estados procesados desde la última llamada.
+	//
+	// La BFS avanza por niveles de distancia. Los movimientos de costo 0 solo desplazan el vacío
+	// entre casillas libres, así que al expandir un estado se expande de una vez toda la región
+	// libre conectada con su vacío (todos esos estados están a la misma distancia); los movimientos
+	// de costo 1 se agregan al nivel siguiente.
+	celdas := b.filas * b.columnas
+	k := len(fichas)
+	p := nuevoPatron(celdas, fichas)
+	for i := range p.tabla {
+		p.tabla[i] = distanciaDesconocida
+	}
+
+	// El vacío es el último dígito del rango extendido, por lo que rango extendido / libres es
+	// el rango de las fichas del grupo en p
+	extendido := nuevoPatron(celdas, append(append([]int(nil), fichas...), 0))
+	distancias := extendido.tabla
+	for i := range distancias {
+		distancias[i] = distanciaDesconocida
+	}
+	expandidos := make([]uint64, (len(distancias)+63)/64) // Estados ya expandidos, como mapa de bits
+	libres := celdas - k                                  // Casillas posibles del vacío para una disposición del grupo
+
+	// Estado objetivo: cada ficha del grupo y el vacío en su casilla dentro del tablero objetivo
+	posiciones := make([]int, k+1)
+	for pos := 0; pos < celdas; pos++ {
+		valor := int(b.objetivo.celdas[pos])
+		if valor == 0 {
+			posiciones[k] = pos
+		}
+		for i, ficha := range fichas {
+			if valor == ficha {
+				posiciones[i] = pos
+			}
+		}
+	}
+	inicio := extendido.rango(posiciones, celdas)
+	distancias[inicio] = 0
+	nivel := []uint32{uint32(inicio)}
+
+	procesados, pendientes := 0, 0 // Estados expandidos en total y aún no reportados
+	for distancia := uint8(0); len(nivel) > 0; distancia++ {
+		siguienteNivel := []uint32{}
+		for _, estado := range nivel {
+			if distancias[estado] != distancia || expandidos[estado/64]&(1<<(estado%64)) != 0 {
+				continue // Entrada obsoleta: el estado ya se expandió con una región anterior
+			}
+			if r := int(estado) / libres; distancia < p.tabla[r] {
+				p.tabla[r] = distancia
+			}
+			extendido.desordenar(int(estado), celdas, posiciones)
+
+			// Casillas ocupadas por fichas del grupo y región libre alcanzable por el vacío
+			ocupadas := uint64(0)
+			for _, pos := range posiciones[:k] {
+				ocupadas |= 1 << pos
+			}
+			vacioInicial := posiciones[k]
+			base := int(estado) - (vacioInicial - bits.OnesCount64(ocupadas&(1<<vacioInicial-1)))
+
+			for region := b.region(1<<vacioInicial, ^ocupadas&(1<<celdas-1)); region != 0; region &= region - 1 {
+				vacio := bits.TrailingZeros64(region)
+				actual := base + vacio - bits.OnesCount64(ocupadas&(1<<vacio-1))
+				distancias[actual] = distancia
+				expandidos[actual/64] |= 1 << (actual % 64)
+
+				// Deslizar una ficha del grupo al vacío: costo 1
+				fila, col := vacio/b.columnas, vacio%b.columnas
+				for _, d := range direcciones {
+					nuevaFila, nuevaCol := fila+d.df, col+d.dc
+					if nuevaFila < 0 || nuevaFila >= b.filas || nuevaCol < 0 || nuevaCol >= b.columnas {
+						continue
+					}
+					destino := nuevaFila*b.columnas + nuevaCol
+					if ocupadas&(1<<destino) == 0 {
+						continue // Casilla libre: pertenece a la región
+					}
+					i := 0
+					for posiciones[i] != destino {
+						i++
+					}
+					posiciones[i], posiciones[k] = vacio, destino
+					vecino := extendido.rango(posiciones, celdas)
+					posiciones[i] = destino
+					if distancias[vecino] == distanciaDesconocida {
+						distancias[vecino] = distancia + 1
+						siguienteNivel = append(siguienteNivel, uint32(vecino))
+					}
+				}
+
+				procesados++
+				if pendientes++; pendientes == 1<<14 {
+					reportar(pendientes)
+					pendientes = 0
+				}
+			}
+		}
+		nivel = siguienteNivel
+	}
+	reportar(pendientes + len(distancias) - procesados) // Estados inalcanzables (irresolubles si el grupo abarca todas las fichas)
+	return p
+}
+
+func (b *BasePatrones) region(inicio, libres uint64) uint64 {
+	// region retorna, como máscara de bits, las casillas de libres conectadas con inicio por
+	// movimientos horizontales y verticales (relleno por inundación).
+	celdas := b.filas * b.columnas
+	var primeraColumna, ultimaColumna uint64
+	for fila := 0; fila < b.filas; fila++ {
+		primeraColumna |= 1 << (fila * b.columnas)
+		ultimaColumna |= 1 << (fila*b.columnas + b.columnas - 1)
+	}
+	tablero := uint64(1)<<celdas - 1
+	region := inicio
+	for {
+		vecinas := region | (region&^primeraColumna)>>1 | (region&^ultimaColumna)<<1 |
+			region>>b.columnas | (region<<b.columnas)&tablero
+		vecinas &= libres
+		if vecinas == region {
+			return region
+		}
+		region = vecinas
+	}
+}
+
+func nuevoPatron(celdas int, fichas []int) patron {
+	// nuevoPatron reserva la tabla de un grupo y precalcula los factores del rango.
+	// El peso de la i-ésima ficha es el número de variaciones de las fichas restantes.
+	k := len(fichas)
+	p := patron{
+		fichas: append([]int(nil), fichas...),
+		pesos:  make([]int, k),
+		tabla:  make([]uint8, variaciones(celdas, k)),
+	}
+	for i := 0; i < k; i++ {
+		p.pesos[i] = variaciones(celdas-1-i, k-1-i)
+	}
+	return p
+}
+
+func (p *patron) rango(posiciones []int, celdas int) int {
+	// rango calcula el índice de una disposición parcial (variación sin repetición) de las fichas.
+	// Cada posición se reduce por el número de posiciones anteriores menores a ella, de modo que
+	// el resultado queda en el intervalo [0, celdas!/(celdas-k)!).
+	r := 0
+	usadas := uint64(0)
+	for i, pos := range posiciones {
+		digito := pos - bits.OnesCount64(usadas&(1<<pos-1))
+		usadas |= 1 << pos
+		r += digito * p.pesos[i]
+	}
+	return r
+}
+
+func (p *patron) desordenar(r, celdas int, posiciones []int) {
+	// desordenar es la operación inversa de rango: escribe en posiciones la disposición de índice r.
+	// Cada dígito indica cuántas casillas libres se saltan, de menor a mayor.
+	libres := uint64(1)<<celdas - 1
+	for i := range posiciones {
+		digito := r / p.pesos[i]
+		r %= p.pesos[i]
+		candidatas := libres
+		for ; digito > 0; digito-- {
+			candidatas &= candidatas - 1 // Descartar la casilla libre más baja
+		}
+		posiciones[i] = bits.TrailingZeros64(candidatas)
+		libres &^= 1 << posiciones[i]
+	}
+}
+
+func variaciones(n, k int) int {
+	// variaciones retorna n!/(n-k)!, el número de formas de ubicar k fichas distintas en n casillas.
+	v := 1
+	for i := 0; i < k; i++ {
+		v *= n - i
+	}
+	return v
+}
+
+func (b *BasePatrones) Evaluar(tablero Tablero) int {
+	// Evaluar calcula h(n) como la suma de las distancias de cada grupo disjunto.
+	// Tiene la firma de Heuristica, por lo que b.Evaluar puede pasarse directamente a
+	// BusquedaAEstrella o BusquedaIDAEstrella.
+	//
+	// Complejidad temporal: O(celdas + k^2 por grupo)
+	celdas := b.filas * b.columnas
+	var ubicacion [MaxCeldas]int // ubicacion[v]: casilla donde se encuentra la ficha v
+	for pos := 0; pos < celdas; pos++ {
+		ubicacion[tablero.celdas[pos]] = pos
+	}
+
+	var posiciones [MaxCeldas]int
+	total := 0
+	for i := range b.patrones {
+		p := &b.patrones[i]
+		for j, ficha := range p.fichas {
+			posiciones[j] = ubicacion[ficha]
+		}
+		total += int(p.tabla[p.rango(posiciones[:len(p.fichas)], celdas)])
+	}
+	return total
+}
+
+func (b *BasePatrones) Guardar(w io.Writer) error {
+	// Guardar serializa la base de patrones en formato binario compacto:
+	// firma "PDB3", filas, columnas y número de grupos (un byte cada uno), las casillas del
+	// objetivo (un byte cada una) y por cada grupo el número de fichas, las fichas y la tabla
+	// de distancias (un byte por entrada).
+	escritor := bufio.NewWriter(w)
+	escritor.Write(firmaBasePatrones[:])
+	escritor.Write([]byte{byte(b.filas), byte(b.columnas), byte(len(b.patrones))})
+	escritor.Write(b.objetivo.celdas[:b.objetivo.Tamano()])
+	for _, p := range b.patrones {
+		escritor.WriteByte(byte(len(p.fichas)))
+		for _, ficha := range p.fichas {
+			escritor.WriteByte(byte(ficha))
+		}
+		escritor.Write(p.tabla)
+	}
+	return escritor.Flush()
+}
+
+func LeerBasePatrones(r io.Reader) (*BasePatrones, error) {
+	// LeerBasePatrones deserializa una base de patrones escrita por Guardar.
+	// Retorna error si el formato no es válido o el contenido está truncado.
+	lector := bufio.NewReader(r)
+	var encabezado struct {
+		Firma    [4]byte
+		Filas    uint8
+		Columnas uint8
+		Grupos   uint8
+	}
+	if err := binary.Read(lector, binary.LittleEndian, &encabezado); err != nil {
+		return nil, fmt.Errorf("encabezado de base de patrones inválido: %w", err)
+	}
+	if encabezado.Firma != firmaBasePatrones {
+		return nil, errors.New("el archivo no es una base de patrones")
+	}
+
+	base := &BasePatrones{filas: int(encabezado.Filas), columnas: int(encabezado.Columnas)}
+	celdas := base.filas * base.columnas
+	if celdas > MaxCeldas {
+		return nil, fmt.Errorf("tamaño %dx%d no admitido", base.filas, base.columnas)
+	}
+
+	casillas := make([]byte, celdas)
+	if _, err := io.ReadFull(lector, casillas); err != nil {
+		return nil, fmt.Errorf("objetivo truncado: %w", err)
+	}
+	valores := make([]int, celdas)
+	for i, valor := range casillas {
+		valores[i] = int(valor)
+	}
+	objetivo, err := NuevoTablero(base.filas, base.columnas, valores)
+	if err != nil {
+		return nil, fmt.Errorf("objetivo inválido: %w", err)
+	}
+	base.objetivo = objetivo
+	for g := 0; g < int(encabezado.Grupos); g++ {
+		k, err := lector.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("grupo %d truncado: %w", g, err)
+		}
+		fichas := make([]byte, k)
+		if _, err := io.ReadFull(lector, fichas); err != nil {
+			return nil, fmt.Errorf("grupo %d truncado: %w", g, err)
+		}
+		grupo := make([]int, k)
+		for i, ficha := range fichas {
+			if ficha == 0 || int(ficha) >= celdas {
+				return nil, fmt.Errorf("grupo %d contiene la ficha inválida %d", g, ficha)
+			}
+			grupo[i] = int(ficha)
+		}
+		p := nuevoPatron(celdas, grupo)
+		if _, err := io.ReadFull(lector, p.tabla); err != nil {
+			return nil, fmt.Errorf("tabla del grupo %d truncada: %w", g, err)
+		}
+		base.patrones = append(base.patrones, p)
+	}
+	return base, nil
+}
+
+func RutaCacheBasePatrones(objetivo Tablero, grupos [][]int) (string, error) {
+	// RutaCacheBasePatrones retorna la ruta del archivo de caché para un objetivo y una partición
+	// de fichas, dentro del directorio de caché del usuario (por ejemplo ~/.cache/puzzle-solver en Linux).
+	// El objetivo se identifica en el nombre mediante un hash FNV-1a de sus casillas.
+	directorio, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	nombres := make([]string, len(grupos))
+	for i, grupo := range grupos {
+		fichas := make([]string, len(grupo))
+		for j, ficha := range grupo {
+			fichas[j] = strconv.Itoa(ficha)
+		}
+		nombres[i] = strings.Join(fichas, ".")
+	}
+	hash := fnv.New32a()
+	hash.Write(objetivo.celdas[:objetivo.Tamano()])
+	nombre := fmt.Sprintf("pdb-%dx%d-%08x-%s.bin", objetivo.filas, objetivo.columnas, hash.Sum32(), strings.Join(nombres, "-"))
+	return filepath.Join(directorio, "puzzle-solver", nombre), nil
+}
+
+func CargarBasePatrones(ruta string, objetivo Tablero, grupos [][]int, progreso func(float64)) (*BasePatrones, error) {
+	// CargarBasePatrones obtiene la base de patrones de forma perezosa:
+	// si el archivo de caché existe y corresponde al objetivo y grupos solicitados se lee del disco;
+	// en caso contrario se construye con ConstruirBasePatrones y se guarda para la próxima ejecución.
+	//
+	// RETORNA: la base de patrones y, si no pudo guardarse la caché, un error informativo.
+	// En ese caso la base retornada es válida y puede utilizarse igualmente.
+	if datos, err := os.ReadFile(ruta); err == nil {
+		if base, err := LeerBasePatrones(bytes.NewReader(datos)); err == nil && base.corresponde(objetivo, grupos) {
+			if progreso != nil {
+				progreso(1)
+			}
+			return base, nil
+		}
+	}
+
+	base := ConstruirBasePatrones(objetivo, grupos, progreso)
+	if err := guardarArchivo(ruta, base.Guardar); err != nil {
+		return base, fmt.Errorf("no se pudo guardar la caché de la base de patrones: %w", err)
+	}
+	return base, nil
+}
+
+func ObtenerBasePatrones(objetivo Tablero, grupos [][]int, progreso func(float64)) (*BasePatrones, error) {
+	// ObtenerBasePatrones retorna la base de patrones desde la caché en disco del usuario (ver
+	// RutaCacheBasePatrones y CargarBasePatrones) o, si no hay un directorio de caché disponible,
+	// la construye en memoria sin guardarla.
+	//
+	// RETORNA: la base de patrones y, si no pudo usarse la caché, un error informativo.
+	// En ese caso la base retornada es válida y puede utilizarse igualmente.
+	ruta, err := RutaCacheBasePatrones(objetivo, grupos)
+	if err != nil {
+		base := ConstruirBasePatrones(objetivo, grupos, progreso)
+		return base, fmt.Errorf("sin directorio de caché, la base de patrones no se guardará: %w", err)
+	}
+	return CargarBasePatrones(ruta, objetivo, grupos, progreso)
+}
+
+func (b *BasePatrones) corresponde(objetivo Tablero, grupos [][]int) bool {
+	// corresponde verifica que la base leída del disco tenga el objetivo y los grupos solicitados.
+	if b.objetivo != objetivo || len(b.patrones) != len(grupos) {
+		return false
+	}
+	for i, grupo := range grupos {
+		if len(b.patrones[i].fichas) != len(grupo) {
+			return false
+		}
+		for j, ficha := range grupo {
+			if b.patrones[i].fichas[j] != ficha {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func guardarArchivo(ruta string, guardar func(w io.Writer) error) error {
+	// guardarArchivo escribe una caché (una base de patrones o una tabla de distancias) en un
+	// archivo temporal y lo renombra al terminar, para que una ejecución interrumpida nunca deje
+	// una caché a medio escribir.
+	if err := os.MkdirAll(filepath.Dir(ruta), 0o755); err != nil {
+		return err
+	}
+	temporal, err := os.CreateTemp(filepath.Dir(ruta), filepath.Base(ruta)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporal.Name())
+
+	if err := guardar(temporal); err != nil {
+		temporal.Close()
+		return err
+	}
+	if err := temporal.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temporal.Name(), ruta)
+}
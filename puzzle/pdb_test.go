@@ -0,0 +1,161 @@
+package puzzle
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBasePatronesDominaManhattan(t *testing.T) {
+	// La base de patrones con el vacío en la abstracción nunca vale menos que Manhattan (cada
+	// grupo cuenta al menos la distancia Manhattan de sus fichas) ni más que la distancia óptima.
+	casos := []struct {
+		nombre   string
+		objetivo Tablero
+	}{
+		{"3x3 estándar", TableroObjetivo(3, 3)},
+		{"3x3 espiral", ObjetivoEspiral(3, 3)},
+		{"2x4 vacío al inicio", ObjetivoVacioInicial(2, 4)},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			base := ConstruirBasePatrones(c.objetivo, GruposPredeterminados(c.objetivo.Filas(), c.objetivo.Columnas()), nil)
+			tabla, err := ConstruirTablaDistancias(c.objetivo, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			manhattan := NuevaHeuristicaManhattan(c.objetivo)
+			tabla.Recorrer(func(tablero Tablero, distancia int) {
+				h := base.Evaluar(tablero)
+				if h < manhattan(tablero) {
+					t.Fatalf("%v: PDB %d menor que Manhattan %d", tablero, h, manhattan(tablero))
+				}
+				if h > distancia {
+					t.Fatalf("%v: PDB %d sobreestima la distancia %d", tablero, h, distancia)
+				}
+			})
+		})
+	}
+}
+
+func TestBasePatronesDominaManhattanMuestra(t *testing.T) {
+	// En tableros mayores se comprueba la dominancia sobre una muestra uniforme.
+	objetivo := TableroObjetivo(3, 4)
+	base := ConstruirBasePatrones(objetivo, GruposPredeterminados(3, 4), nil)
+	manhattan := NuevaHeuristicaManhattan(objetivo)
+	rng := NuevoGenerador(1)
+	for i := 0; i < 2000; i++ {
+		tablero := MezclarUniforme(objetivo, rng)
+		if h, m := base.Evaluar(tablero), manhattan(tablero); h < m {
+			t.Fatalf("%v: PDB %d menor que Manhattan %d", tablero, h, m)
+		}
+	}
+}
+
+func TestRangoDesordenar(t *testing.T) {
+	// rango y desordenar son inversas y recorren todas las variaciones sin huecos.
+	casos := []struct {
+		celdas int
+		fichas []int
+	}{
+		{9, []int{1, 2, 3, 4}},
+		{9, []int{1, 2, 3, 4, 0}},
+		{12, []int{1, 2, 3}},
+		{6, []int{0, 1, 2, 3}},
+	}
+	for _, c := range casos {
+		p := nuevoPatron(c.celdas, c.fichas)
+		posiciones := make([]int, len(c.fichas))
+		vistas := map[[MaxCeldas]int]bool{}
+		for r := range p.tabla {
+			p.desordenar(r, c.celdas, posiciones)
+			if got := p.rango(posiciones, c.celdas); got != r {
+				t.Fatalf("celdas %d, fichas %v: rango(desordenar(%d)) = %d", c.celdas, c.fichas, r, got)
+			}
+			var clave [MaxCeldas]int
+			copy(clave[:], posiciones)
+			if vistas[clave] {
+				t.Fatalf("celdas %d, fichas %v: disposición %v repetida", c.celdas, c.fichas, posiciones)
+			}
+			vistas[clave] = true
+		}
+	}
+}
+
+func TestBasePatronesGuardarLeer(t *testing.T) {
+	// La base leída de su serialización tiene el mismo objetivo, grupos y tablas que la original.
+	objetivo := ObjetivoEspiral(2, 4)
+	grupos := GruposPredeterminados(2, 4)
+	base := ConstruirBasePatrones(objetivo, grupos, nil)
+	var buffer bytes.Buffer
+	if err := base.Guardar(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	leida, err := LeerBasePatrones(bytes.NewReader(buffer.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !leida.corresponde(objetivo, grupos) {
+		t.Fatal("la base leída no corresponde al objetivo y los grupos guardados")
+	}
+	for i := range base.patrones {
+		if !bytes.Equal(leida.patrones[i].tabla, base.patrones[i].tabla) {
+			t.Fatalf("la tabla del grupo %d no coincide", i)
+		}
+	}
+
+	antigua := bytes.Clone(buffer.Bytes())
+	antigua[3] = '2'
+	if _, err := LeerBasePatrones(bytes.NewReader(antigua)); err == nil {
+		t.Fatal("se esperaba un error con la firma de una versión anterior")
+	}
+	if _, err := LeerBasePatrones(bytes.NewReader(buffer.Bytes()[:buffer.Len()-1])); err == nil {
+		t.Fatal("se esperaba un error con una tabla truncada")
+	}
+}
+
+func TestCargarBasePatrones(t *testing.T) {
+	// CargarBasePatrones lee la caché válida sin reconstruirla, y reconstruye y reemplaza la de
+	// una versión anterior o la de otras dimensiones.
+	objetivo := TableroObjetivo(2, 4)
+	grupos := GruposPredeterminados(2, 4)
+	ruta := filepath.Join(t.TempDir(), "pdb.bin")
+	cargar := func(objetivo Tablero, grupos [][]int) (*BasePatrones, bool) {
+		construida := false
+		base, err := CargarBasePatrones(ruta, objetivo, grupos, func(avance float64) {
+			construida = construida || avance < 1
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !base.corresponde(objetivo, grupos) {
+			t.Fatalf("la base cargada no corresponde a %v", objetivo)
+		}
+		return base, construida
+	}
+
+	if _, construida := cargar(objetivo, grupos); !construida {
+		t.Fatal("sin caché la base debería construirse")
+	}
+	if _, construida := cargar(objetivo, grupos); construida {
+		t.Fatal("con la caché guardada la base no debería reconstruirse")
+	}
+
+	datos, err := os.ReadFile(ruta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	datos[3] = '2'
+	if err := os.WriteFile(ruta, datos, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, construida := cargar(objetivo, grupos); !construida {
+		t.Fatal("una caché con la firma anterior debería reconstruirse")
+	}
+
+	transpuesto := TableroObjetivo(4, 2)
+	if _, construida := cargar(transpuesto, GruposPredeterminados(4, 2)); !construida {
+		t.Fatal("una caché de otras dimensiones debería reconstruirse")
+	}
+}
@@ -0,0 +1,132 @@
+package puzzle
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+)
+
+// maxReinicios limita los reinicios de la caminata de MezclarProfundidad antes de rendirse,
+// por ejemplo cuando la profundidad pedida supera el diámetro del espacio de estados.
+const maxReinicios = 1000
+
+func MezclarProfundidad(ctx context.Context, objetivo Tablero, profundidad int, rng *rand.Rand) (Tablero, error) {
+	// MezclarProfundidad genera un tablero cuya solución óptima tiene exactamente profundidad
+	// movimientos, verificando cada distancia con IDA* y Manhattan + Conflicto Lineal:
+	//
+	// 1. Camina profundidad pasos al azar desde el objetivo sin deshacer el paso anterior
+	// 2. Calcula la distancia óptima d del tablero alcanzado (d tiene la paridad de profundidad)
+	// 3. Mientras d sea menor, avanza a un vecino al azar que esté a distancia d+1
+	// 4. Si ningún vecino se aleja más del objetivo (un máximo local), reinicia desde 1
+	//
+	// PARÁMETROS:
+	// - ctx: contexto de cancelación; en tableros 4x4 o mayores las verificaciones pueden tardar
+	// - objetivo: configuración objetivo (la distancia se mide hacia ella)
+	// - profundidad: longitud exacta de la solución óptima deseada
+	// - rng: generador aleatorio; la misma semilla produce el mismo tablero
+	//
+	// RETORNA: el tablero, o un error si se canceló o no se alcanzó la profundidad (por ejemplo,
+	// más de 31 movimientos en el 8-puzzle)
+	if profundidad < 0 {
+		return objetivo, fmt.Errorf("profundidad %d inválida", profundidad)
+	}
+	heuristica := NuevaHeuristicaConflictoLineal(objetivo)
+	for reinicio := 0; reinicio < maxReinicios; reinicio++ {
+		tablero, anterior := objetivo, -1
+		for i := 0; i < profundidad; i++ {
+			tablero, anterior = pasoAleatorio(tablero, anterior, rng)
+		}
+		distancia, err := distanciaOptima(ctx, tablero, objetivo, heuristica)
+		if err != nil {
+			return objetivo, err
+		}
+
+		for distancia < profundidad {
+			candidatos := []Tablero{}
+			for _, sucesor := range GenerarMovimientos(tablero) {
+				d, err := distanciaOptima(ctx, sucesor.Tablero, objetivo, heuristica)
+				if err != nil {
+					return objetivo, err
+				}
+				if d > distancia {
+					candidatos = append(candidatos, sucesor.Tablero)
+				}
+			}
+			if len(candidatos) == 0 {
+				break // Máximo local: reiniciar
+			}
+			tablero = candidatos[rng.IntN(len(candidatos))]
+			distancia++
+		}
+		if distancia == profundidad {
+			return tablero, nil
+		}
+	}
+	return objetivo, fmt.Errorf("no se encontró un tablero a %d movimientos del objetivo tras %d intentos", profundidad, maxReinicios)
+}
+
+func distanciaOptima(ctx context.Context, tablero, objetivo Tablero, heuristica Heuristica) (int, error) {
+	// distanciaOptima retorna la longitud de la solución óptima del tablero (IDA* es admisible).
+	resultado, err := BusquedaIDAEstrella(ctx, tablero, objetivo, heuristica, nil)
+	if err != nil {
+		return 0, err
+	}
+	return len(resultado.Camino) - 1, nil
+}
+
+func pasoAleatorio(tablero Tablero, anterior int, rng *rand.Rand) (Tablero, int) {
+	// pasoAleatorio mueve el vacío en una dirección al azar que no deshaga la dirección anterior
+	// (-1 si no hay) y retorna el tablero resultante junto con la dirección aplicada.
+	posVacio := EncontrarVacio(tablero)
+	fila, col := posVacio/tablero.columnas, posVacio%tablero.columnas
+	validas := make([]int, 0, len(direcciones))
+	for i, d := range direcciones {
+		nuevaFila, nuevaCol := fila+d.df, col+d.dc
+		if nuevaFila < 0 || nuevaFila >= tablero.filas || nuevaCol < 0 || nuevaCol >= tablero.columnas {
+			continue
+		}
+		if anterior >= 0 && i == anterior^1 {
+			continue // Deshace el movimiento anterior
+		}
+		validas = append(validas, i)
+	}
+
+	i := validas[rng.IntN(len(validas))]
+	nuevaPos := (fila+direcciones[i].df)*tablero.columnas + col + direcciones[i].dc
+	tablero.celdas[posVacio], tablero.celdas[nuevaPos] = tablero.celdas[nuevaPos], tablero.celdas[posVacio]
+	return tablero, i
+}
+
+func MezclarDistancia(ctx context.Context, objetivo Tablero, profundidad int, tabla *TablaDistancias, rng *rand.Rand) (Tablero, error) {
+	// MezclarDistancia genera un tablero cuya solución óptima tiene exactamente profundidad
+	// movimientos con el mejor método disponible para su tamaño: hasta MaxCeldasTablaDistancias
+	// casillas lo elige uniformemente entre todos los estados a esa distancia de la tabla de
+	// distancias; en tableros mayores recurre a MezclarProfundidad.
+	//
+	// PARÁMETROS:
+	// - ctx: contexto de cancelación (ver MezclarProfundidad)
+	// - objetivo: configuración objetivo (la distancia se mide hacia ella)
+	// - profundidad: longitud exacta de la solución óptima deseada
+	// - tabla: tabla de distancias del objetivo, por ejemplo cargada de la caché, o nil; si es nil
+	//   y el tablero es pequeño se construye en memoria, por lo que conviene pasarla al generar varios
+	// - rng: generador aleatorio; la misma semilla produce el mismo tablero
+	//
+	// RETORNA: el tablero, o un error si no hay tableros a esa distancia o se canceló la búsqueda
+	if tabla == nil && objetivo.Tamano() <= MaxCeldasTablaDistancias {
+		var err error
+		if tabla, err = ConstruirTablaDistancias(objetivo, nil); err != nil {
+			return objetivo, err
+		}
+	}
+	if tabla == nil {
+		return MezclarProfundidad(ctx, objetivo, profundidad, rng)
+	}
+	if tabla.Objetivo() != objetivo {
+		return objetivo, fmt.Errorf("la tabla de distancias corresponde al objetivo %v, no a %v", tabla.Objetivo(), objetivo)
+	}
+	tablero, existe := tabla.Aleatorio(profundidad, rng)
+	if !existe {
+		return objetivo, fmt.Errorf("no hay tableros a %d movimientos del objetivo (el máximo es %d)", profundidad, len(tabla.Histograma())-1)
+	}
+	return tablero, nil
+}
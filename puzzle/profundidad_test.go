@@ -0,0 +1,61 @@
+package puzzle
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMezclarProfundidad(t *testing.T) {
+	// Los tableros generados están exactamente a la profundidad pedida según la tabla de distancias.
+	objetivo := TableroObjetivo(3, 3)
+	tabla, err := ConstruirTablaDistancias(objetivo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := NuevoGenerador(1)
+	for _, profundidad := range []int{0, 1, 5, 12, 20, 26} {
+		tablero, err := MezclarProfundidad(context.Background(), objetivo, profundidad, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := tabla.Distancia(tablero); d != profundidad {
+			t.Fatalf("profundidad %d: %v está a %d movimientos", profundidad, tablero, d)
+		}
+	}
+	if _, err := MezclarProfundidad(context.Background(), objetivo, -1, rng); err == nil {
+		t.Fatal("se esperaba un error con profundidad negativa")
+	}
+}
+
+func TestMezclarDistancia(t *testing.T) {
+	// MezclarDistancia alcanza el diámetro del 8-puzzle con o sin tabla, recurre a la búsqueda en
+	// tableros mayores y rechaza distancias inexistentes o una tabla de otro objetivo.
+	objetivo := TableroObjetivo(3, 3)
+	tabla, err := ConstruirTablaDistancias(objetivo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := NuevoGenerador(1)
+	for _, usada := range []*TablaDistancias{tabla, nil} {
+		tablero, err := MezclarDistancia(context.Background(), objetivo, 31, usada, rng)
+		if err != nil || tabla.Distancia(tablero) != 31 {
+			t.Fatalf("MezclarDistancia(31) = %v, %v", tablero, err)
+		}
+	}
+	if _, err := MezclarDistancia(context.Background(), objetivo, 32, tabla, rng); err == nil {
+		t.Fatal("se esperaba un error a 32 movimientos del objetivo")
+	}
+	if _, err := MezclarDistancia(context.Background(), ObjetivoEspiral(3, 3), 10, tabla, rng); err == nil {
+		t.Fatal("se esperaba un error con la tabla de otro objetivo")
+	}
+
+	mayor := TableroObjetivo(3, 4)
+	tablero, err := MezclarDistancia(context.Background(), mayor, 12, nil, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resultado, err := BusquedaIDAEstrella(context.Background(), tablero, mayor, NuevaHeuristicaConflictoLineal(mayor), nil)
+	if err != nil || len(resultado.Camino)-1 != 12 {
+		t.Fatalf("%v: solución de %d movimientos, se esperaban 12 (%v)", tablero, len(resultado.Camino)-1, err)
+	}
+}
@@ -0,0 +1,70 @@
+package puzzle
+
+import (
+	"context"
+	"time"
+)
+
+// IntervaloProgreso es el tiempo mínimo entre dos reportes de progreso de una búsqueda,
+// suficiente para refrescar una interfaz varias veces por segundo sin saturarla.
+const IntervaloProgreso = 200 * time.Millisecond
+
+// intervaloCancelacion es el número de nodos expandidos entre dos consultas al contexto y al reloj.
+// Consultarlos en cada nodo encarecería la búsqueda sin mejorar la respuesta perceptible.
+const intervaloCancelacion = 1024
+
+// Progreso es una instantánea del avance de una búsqueda, entregada periódicamente a la función
+// de progreso que reciben BusquedaAEstrella, BusquedaIDAEstrella y BusquedaAnchura.
+type Progreso struct {
+	Expandidos   int           // Nodos expandidos hasta el momento
+	Frontera     int           // Tamaño de la frontera: lista ABIERTA (A*), cola FIFO (BFS) o camino actual (IDA*)
+	MejorF       int           // Menor f(n) pendiente (A*), cota de la iteración (IDA*) o nivel explorado (BFS)
+	Profundidad  int           // g(n) del último nodo expandido
+	Transcurrido time.Duration // Tiempo desde el inicio de la búsqueda
+	Terminado    bool          // true en el último reporte: solución encontrada, búsqueda agotada o cancelada
+}
+
+// monitor reúne las tareas periódicas de una búsqueda: atender la cancelación del contexto
+// y reportar el progreso como máximo una vez cada IntervaloProgreso.
+type monitor struct {
+	ctx      context.Context // Contexto de cancelación de la búsqueda
+	progreso func(Progreso)  // Función de progreso opcional (puede ser nil)
+	inicio   time.Time       // Momento en que comenzó la búsqueda
+	ultimo   time.Time       // Momento del último reporte enviado
+	nodos    int             // Llamadas a pulso desde el inicio
+}
+
+func nuevoMonitor(ctx context.Context, progreso func(Progreso)) *monitor {
+	// nuevoMonitor inicia el reloj de la búsqueda.
+	ahora := time.Now()
+	return &monitor{ctx: ctx, progreso: progreso, inicio: ahora, ultimo: ahora}
+}
+
+func (m *monitor) pulso() bool {
+	// pulso se invoca una vez por nodo expandido y retorna true cada intervaloCancelacion nodos,
+	// indicando a la búsqueda que debe llamar a revisar.
+	m.nodos++
+	return m.nodos%intervaloCancelacion == 0
+}
+
+func (m *monitor) revisar(p Progreso) error {
+	// revisar reporta el progreso si pasó IntervaloProgreso desde el último reporte y
+	// retorna el error del contexto si la búsqueda fue cancelada.
+	if m.progreso != nil {
+		if ahora := time.Now(); ahora.Sub(m.ultimo) >= IntervaloProgreso {
+			m.ultimo = ahora
+			p.Transcurrido = ahora.Sub(m.inicio)
+			m.progreso(p)
+		}
+	}
+	return m.ctx.Err()
+}
+
+func (m *monitor) finalizar(p Progreso) {
+	// finalizar envía el reporte final de la búsqueda, sin importar el intervalo.
+	if m.progreso != nil {
+		p.Transcurrido = time.Since(m.inicio)
+		p.Terminado = true
+		m.progreso(p)
+	}
+}
@@ -0,0 +1,93 @@
+package puzzle
+
+import (
+	"context"
+	"testing"
+)
+
+// busquedaConProgreso ejecuta una búsqueda con el contexto y la función de progreso recibidos.
+type busquedaConProgreso func(ctx context.Context, inicial, objetivo Tablero, progreso func(Progreso)) (Resultado, error)
+
+func busquedasConProgreso(objetivo Tablero) map[string]busquedaConProgreso {
+	// busquedasConProgreso retorna los tres algoritmos con la firma común de busquedaConProgreso.
+	heuristica := NuevaHeuristicaManhattan(objetivo)
+	return map[string]busquedaConProgreso{
+		"A*": func(ctx context.Context, inicial, objetivo Tablero, progreso func(Progreso)) (Resultado, error) {
+			return BusquedaAEstrella(ctx, inicial, objetivo, heuristica, progreso)
+		},
+		"IDA*": func(ctx context.Context, inicial, objetivo Tablero, progreso func(Progreso)) (Resultado, error) {
+			return BusquedaIDAEstrella(ctx, inicial, objetivo, heuristica, progreso)
+		},
+		"BFS": BusquedaAnchura,
+	}
+}
+
+func comprobarReportes(t *testing.T, reportes []Progreso) {
+	// comprobarReportes verifica que solo el último reporte esté marcado como terminado y que
+	// los nodos expandidos y el tiempo no retrocedan.
+	t.Helper()
+	if len(reportes) == 0 {
+		t.Fatal("no llegó ningún reporte de progreso")
+	}
+	for i, p := range reportes {
+		if p.Terminado != (i == len(reportes)-1) {
+			t.Fatalf("reporte %d de %d con Terminado = %v", i+1, len(reportes), p.Terminado)
+		}
+		if i > 0 && (p.Expandidos < reportes[i-1].Expandidos || p.Transcurrido < reportes[i-1].Transcurrido) {
+			t.Fatalf("el reporte %d retrocede: %+v tras %+v", i+1, p, reportes[i-1])
+		}
+	}
+}
+
+func TestProgresoBusquedaTerminada(t *testing.T) {
+	// Una búsqueda que encuentra la solución envía un único reporte final con los nodos
+	// expandidos de sus estadísticas.
+	objetivo := TableroObjetivo(3, 3)
+	inicial, err := ParsearTablero(3, 3, "8 6 7 2 5 4 3 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for nombre, buscar := range busquedasConProgreso(objetivo) {
+		t.Run(nombre, func(t *testing.T) {
+			reportes := []Progreso{}
+			resultado, err := buscar(context.Background(), inicial, objetivo, func(p Progreso) {
+				reportes = append(reportes, p)
+			})
+			if err != nil || len(resultado.Camino) == 0 {
+				t.Fatalf("búsqueda sin solución: %v", err)
+			}
+			comprobarReportes(t, reportes)
+			if final := reportes[len(reportes)-1]; final.Expandidos != resultado.Estadisticas.NodosExpandidos {
+				t.Fatalf("el reporte final informa %d nodos expandidos, las estadísticas %d", final.Expandidos, resultado.Estadisticas.NodosExpandidos)
+			}
+		})
+	}
+}
+
+func TestProgresoCancelacion(t *testing.T) {
+	// En una búsqueda larga llegan reportes intermedios; cancelar el contexto desde el primero
+	// detiene la búsqueda, que retorna ctx.Err() tras un último reporte terminado.
+	objetivo := TableroObjetivo(4, 4)
+	inicial := MezclarUniforme(objetivo, NuevoGenerador(1))
+	for nombre, buscar := range busquedasConProgreso(objetivo) {
+		t.Run(nombre, func(t *testing.T) {
+			ctx, cancelar := context.WithCancel(context.Background())
+			defer cancelar()
+			reportes := []Progreso{}
+			resultado, err := buscar(ctx, inicial, objetivo, func(p Progreso) {
+				reportes = append(reportes, p)
+				cancelar()
+			})
+			if err != context.Canceled {
+				t.Fatalf("err = %v, se esperaba context.Canceled", err)
+			}
+			if len(resultado.Camino) != 0 {
+				t.Fatal("una búsqueda cancelada no debe retornar camino")
+			}
+			if len(reportes) != 2 {
+				t.Fatalf("llegaron %d reportes, se esperaban el intermedio que canceló y el final", len(reportes))
+			}
+			comprobarReportes(t, reportes)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Package puzzle contiene el núcleo de resolución del N-puzzle, independiente de la interfaz gráfica.
+
+DESCRIPCIÓN:
+Este paquete expone la representación del tablero, la generación de movimientos, las funciones
+heurísticas y los algoritmos de búsqueda utilizados por la aplicación. No depende de Fyne ni de
+ninguna otra biblioteca gráfica, por lo que puede importarse desde herramientas de línea de
+comandos, experimentos o pruebas unitarias. Admite tableros rectangulares de 2 a 5 filas y
+columnas: desde 2x3 hasta 5x5 (24-puzzle), pasando por 3x3 (8-puzzle) y 4x4 (15-puzzle).
+
+API PÚBLICA:
+  - Tablero: configuración del puzzle de filas x columnas (el valor 0 representa el espacio vacío)
+  - Estado: nodo del árbol de búsqueda con referencia al padre, costo y acción aplicada
+  - GenerarMovimientos, EncontrarVacio, EsObjetivo: operaciones básicas sobre el tablero
+  - EsResoluble, AnalizarResolubilidad: verificación de resolubilidad por paridad de inversiones
+  - Heuristica, NuevaHeuristicaManhattan, NuevaHeuristicaConflictoLineal, BasePatrones:
+    heurísticas intercambiables construidas para un objetivo concreto
+  - TableroObjetivo, ObjetivoVacioInicial, ObjetivoEspiral: configuraciones objetivo predefinidas
+  - BusquedaAEstrella, BusquedaAnchura: algoritmos de búsqueda informada y no informada
+  - BusquedaIDAEstrella: A* de profundización iterativa con memoria lineal en la profundidad
+  - Resultado, Estadisticas: camino encontrado y métricas de rendimiento de cada búsqueda
+  - NuevoGenerador: generador aleatorio con semilla que reciben todas las funciones de mezcla
+  - MezclarAleatorio: generación de tableros resolubles mediante movimientos aleatorios
+  - MezclarUniforme: tableros resolubles elegidos con distribución uniforme
+  - MezclarProfundidad: tableros cuya solución óptima tiene una longitud exacta
+  - MezclarDistancia: longitud exacta con la tabla de distancias hasta 3x3 o MezclarProfundidad
+  - TablaDistancias: distancia óptima exacta de todos los estados hasta 3x3 (181.440 en el 8-puzzle)
+  - ObtenerBasePatrones, ObtenerTablaDistancias: bases y tablas leídas de la caché en disco o construidas
+  - Sesion: partida con historial de movimientos para deshacer y rehacer
+
+Todas las búsquedas reciben un context.Context y se detienen al cancelarse, retornando ctx.Err().
+También aceptan una función de progreso opcional que recibe un Progreso (nodos expandidos,
+tamaño de la frontera, mejor f, profundidad y tiempo transcurrido) varias veces por segundo.
+
+EJEMPLO DE USO:
+
+	inicial, _ := puzzle.ParsearTablero(3, 3, "1 2 3 4 0 6 7 5 8")
+	objetivo := puzzle.TableroObjetivo(3, 3)
+	resultado, _ := puzzle.BusquedaAEstrella(context.Background(), inicial, objetivo, puzzle.NuevaHeuristicaConflictoLineal(objetivo), nil)
+	for _, estado := range resultado.Camino {
+		fmt.Println(estado.Accion, estado.Tablero)
+	}
+*/
+package puzzle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Límites de tamaño del tablero admitidos por el paquete (filas y columnas por separado).
+const (
+	DimensionMinima = 2                                 // Mínimo de filas o columnas (por ejemplo 2x3)
+	DimensionMaxima = 5                                 // Máximo de filas o columnas (por ejemplo 5x5)
+	MaxCeldas       = DimensionMaxima * DimensionMaxima // Capacidad del arreglo interno de casillas
+)
+
+// Tablero representa una configuración del puzzle deslizante en orden de filas.
+// Las posiciones 0..filas*columnas-1 recorren el tablero de izquierda a derecha y de arriba hacia abajo;
+// el valor 0 representa el espacio vacío. Internamente usa un arreglo de capacidad fija, por lo
+// que se copia por valor sin reservar memoria y dos tableros pueden compararse con ==.
+type Tablero struct {
+	celdas   [MaxCeldas]uint8 // Valor de cada casilla; solo las primeras filas*columnas son significativas
+	filas    int              // Número de filas del tablero
+	columnas int              // Número de columnas del tablero
+}
+
+// Estado representa un nodo en el árbol de búsqueda del puzzle deslizante.
+// Contiene la configuración actual del tablero, referencias para reconstruir el camino,
+// y metainformación para los algoritmos de búsqueda.
+type Estado struct {
+	Tablero    Tablero // Configuración actual del tablero, valor 0 representa espacio vacío
+	Padre      *Estado // Referencia al estado padre para reconstruir la solución
+	Costo      int     // g(n): Costo acumulado desde el estado inicial (profundidad)
+	Estimacion int     // h(n): Valor heurístico calculado una sola vez al generar el nodo
+	Accion     string  // Acción realizada para llegar a este estado desde el padre
+}
+
+// direccion describe un desplazamiento del espacio vacío sobre el tablero.
+type direccion struct {
+	df, dc int    // Desplazamiento en filas y columnas
+	accion string // Nombre de la acción mostrada al usuario
+}
+
+// direcciones lista los movimientos del vacío; el opuesto de la dirección i es i^1.
+var direcciones = [4]direccion{
+	{-1, 0, "Arriba"},
+	{1, 0, "Abajo"},
+	{0, -1, "Izquierda"},
+	{0, 1, "Derecha"},
+}
+
+func NuevoTablero(filas, columnas int, valores []int) (Tablero, error) {
+	// NuevoTablero construye un tablero de filas x columnas a partir de sus valores en orden de filas.
+	// Valida que las dimensiones estén dentro de los límites y que los valores sean una permutación
+	// de 0..filas*columnas-1 (cada ficha exactamente una vez y un único espacio vacío).
+	var tablero Tablero
+	if filas < DimensionMinima || filas > DimensionMaxima || columnas < DimensionMinima || columnas > DimensionMaxima {
+		return tablero, fmt.Errorf("tamaño %dx%d no admitido (filas y columnas de %d a %d)", filas, columnas, DimensionMinima, DimensionMaxima)
+	}
+	celdas := filas * columnas
+	if len(valores) != celdas {
+		return tablero, fmt.Errorf("un tablero %dx%d necesita %d valores, se recibieron %d", filas, columnas, celdas, len(valores))
+	}
+
+	vistos := make([]bool, celdas)
+	for _, valor := range valores {
+		if valor < 0 || valor >= celdas {
+			return tablero, fmt.Errorf("valor %d fuera de rango (0-%d)", valor, celdas-1)
+		}
+		if vistos[valor] {
+			return tablero, fmt.Errorf("valor %d repetido", valor)
+		}
+		vistos[valor] = true
+	}
+
+	tablero.filas, tablero.columnas = filas, columnas
+	for i, valor := range valores {
+		tablero.celdas[i] = uint8(valor)
+	}
+	return tablero, nil
+}
+
+func ParsearTablero(filas, columnas int, texto string) (Tablero, error) {
+	// ParsearTablero interpreta un tablero escrito como texto, por ejemplo "1 2 3 4 0 6 7 5 8".
+	// Los valores pueden separarse con espacios, comas, punto y coma o saltos de línea.
+	// Aplica las mismas validaciones que NuevoTablero.
+	campos := strings.FieldsFunc(texto, func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';' || r == '\t' || r == '\n' || r == '\r'
+	})
+	valores := make([]int, len(campos))
+	for i, campo := range campos {
+		valor, err := strconv.Atoi(campo)
+		if err != nil {
+			return Tablero{}, fmt.Errorf("valor %q no es un número entero", campo)
+		}
+		valores[i] = valor
+	}
+	return NuevoTablero(filas, columnas, valores)
+}
+
+func TableroObjetivo(filas, columnas int) Tablero {
+	// TableroObjetivo retorna la configuración objetivo estándar derivada de las dimensiones:
+	// fichas 1..filas*columnas-1 en orden de filas y el espacio vacío en la última casilla.
+	tablero := Tablero{filas: filas, columnas: columnas}
+	celdas := filas * columnas
+	for i := 0; i < celdas-1; i++ {
+		tablero.celdas[i] = uint8(i + 1)
+	}
+	return tablero
+}
+
+func (t Tablero) Filas() int {
+	// Filas retorna el número de filas del tablero.
+	return t.filas
+}
+
+func (t Tablero) Columnas() int {
+	// Columnas retorna el número de columnas del tablero.
+	return t.columnas
+}
+
+func (t Tablero) Tamano() int {
+	// Tamano retorna el número de casillas del tablero (filas x columnas).
+	return t.filas * t.columnas
+}
+
+func (t Tablero) Valor(pos int) int {
+	// Valor retorna la ficha ubicada en la posición indicada (0 para el espacio vacío).
+	return int(t.celdas[pos])
+}
+
+func (t Tablero) Valores() []int {
+	// Valores retorna las fichas del tablero en orden de filas.
+	valores := make([]int, t.Tamano())
+	for i := range valores {
+		valores[i] = int(t.celdas[i])
+	}
+	return valores
+}
+
+func (t Tablero) Intercambiar(i, j int) Tablero {
+	// Intercambiar retorna una copia del tablero con las casillas i y j intercambiadas.
+	// No es un movimiento del puzzle: sirve para editar configuraciones y puede cambiar la
+	// resolubilidad del tablero (intercambiar dos fichas invierte la paridad de las inversiones).
+	t.celdas[i], t.celdas[j] = t.celdas[j], t.celdas[i]
+	return t
+}
+
+func (t Tablero) String() string {
+	// String retorna las fichas separadas por espacios, por ejemplo "1 2 3 4 0 6 7 5 8".
+	partes := make([]string, t.Tamano())
+	for i := range partes {
+		partes[i] = strconv.Itoa(int(t.celdas[i]))
+	}
+	return strings.Join(partes, " ")
+}
+
+func EncontrarVacio(tablero Tablero) int {
+	// EncontrarVacio localiza y retorna la posición del espacio vacío (representado por 0) en el tablero.
+	// Es una función auxiliar fundamental para generar movimientos válidos.
+	// Retorna: índice de la posición vacía, o -1 si no se encuentra.
+	for i := 0; i < tablero.Tamano(); i++ {
+		if tablero.celdas[i] == 0 {
+			return i
+		}
+	}
+	return -1 // No debería ocurrir en un puzzle válido
+}
+
+func EsObjetivo(tablero Tablero, objetivo Tablero) bool {
+	// EsObjetivo verifica si la configuración actual del tablero coincide con el estado objetivo.
+	// Es la condición de parada para los algoritmos de búsqueda.
+	// Retorna: true si el puzzle está resuelto, false en caso contrario.
+	return tablero == objetivo
+}
+
+func GenerarMovimientos(tablero Tablero) []Estado {
+	// GenerarMovimientos genera todos los movimientos válidos desde el estado actual del tablero.
+	// Un movimiento válido consiste en intercambiar el espacio vacío con una ficha adyacente
+	// (arriba, abajo, izquierda, derecha).
+	//
+	// Retorna: slice de Estados representando todos los sucesores posibles.
+	movimientos := make([]Estado, 0, len(direcciones))
+	posVacio := EncontrarVacio(tablero)
+
+	// Convertir posición lineal a coordenadas 2D
+	fila := posVacio / tablero.columnas
+	col := posVacio % tablero.columnas
+
+	for _, d := range direcciones {
+		nuevaFila, nuevaCol := fila+d.df, col+d.dc
+		if nuevaFila < 0 || nuevaFila >= tablero.filas || nuevaCol < 0 || nuevaCol >= tablero.columnas {
+			continue
+		}
+
+		// Intercambiar el vacío con la ficha adyacente en la dirección d
+		nuevo := tablero
+		nuevaPos := nuevaFila*tablero.columnas + nuevaCol
+		nuevo.celdas[posVacio], nuevo.celdas[nuevaPos] = nuevo.celdas[nuevaPos], nuevo.celdas[posVacio]
+		movimientos = append(movimientos, Estado{
+			Tablero: nuevo,
+			Accion:  d.accion,
+		})
+	}
+
+	return movimientos
+}
@@ -0,0 +1,117 @@
+package puzzle
+
+import "fmt"
+
+// AnalisisResolubilidad detalla el cálculo de paridad que decide si un tablero puede llevarse
+// al objetivo, para poder explicar al usuario por qué una configuración no tiene solución.
+type AnalisisResolubilidad struct {
+	Resoluble         bool   // true si el objetivo es alcanzable desde el tablero
+	Inversiones       int    // Pares de fichas en orden inverso al que tienen en el objetivo
+	ColumnasPares     bool   // Con columnas pares la fila del vacío también cuenta en la paridad
+	FilaVacio         int    // Fila del vacío en el tablero (0 es la fila superior)
+	FilaVacioObjetivo int    // Fila del vacío en el objetivo (0 es la fila superior)
+	Paridad           int    // Inversiones, más la diferencia de filas del vacío si las columnas son pares
+	Explicacion       string // Descripción en lenguaje natural del veredicto
+}
+
+func EsResoluble(tablero Tablero, objetivo Tablero) bool {
+	// EsResoluble determina si el objetivo es alcanzable desde el tablero mediante la paridad
+	// de inversiones, válida para tableros cuadrados y rectangulares.
+	// Ver AnalizarResolubilidad para el detalle del cálculo.
+	return AnalizarResolubilidad(tablero, objetivo).Resoluble
+}
+
+func AnalizarResolubilidad(tablero Tablero, objetivo Tablero) AnalisisResolubilidad {
+	// AnalizarResolubilidad calcula la paridad de inversiones del tablero respecto al objetivo
+	// y retorna el veredicto junto con los valores que lo justifican.
+	//
+	// REGLA DE PARIDAD:
+	// - Un movimiento horizontal del vacío no cambia el orden de las fichas (sin contar el vacío)
+	// - Un movimiento vertical desplaza una ficha sobre columnas-1 fichas, cambiando la paridad
+	//   de las inversiones solo si el número de columnas es par
+	// - Columnas impares: resoluble si y solo si las inversiones son pares
+	// - Columnas pares: resoluble si y solo si inversiones + diferencia de fila del vacío es par
+	//
+	// Las inversiones se cuentan respecto al orden de las fichas en el objetivo, por lo que
+	// la regla es válida para cualquier configuración objetivo de las mismas dimensiones.
+	//
+	// Complejidad temporal: O(n²) con n = filas x columnas
+	if tablero.filas != objetivo.filas || tablero.columnas != objetivo.columnas {
+		return AnalisisResolubilidad{
+			Explicacion: fmt.Sprintf("El tablero es de %dx%d y el objetivo de %dx%d: las dimensiones deben coincidir.",
+				tablero.filas, tablero.columnas, objetivo.filas, objetivo.columnas),
+		}
+	}
+
+	analisis := AnalisisResolubilidad{
+		Inversiones:       inversiones(tablero, objetivo),
+		ColumnasPares:     tablero.columnas%2 == 0,
+		FilaVacio:         EncontrarVacio(tablero) / tablero.columnas,
+		FilaVacioObjetivo: EncontrarVacio(objetivo) / objetivo.columnas,
+	}
+	analisis.Paridad = analisis.Inversiones
+	if analisis.ColumnasPares {
+		analisis.Paridad += abs(analisis.FilaVacio - analisis.FilaVacioObjetivo)
+	}
+	analisis.Resoluble = analisis.Paridad%2 == 0
+	analisis.Explicacion = analisis.explicar(tablero.columnas)
+	return analisis
+}
+
+func (a AnalisisResolubilidad) explicar(columnas int) string {
+	// explicar redacta el veredicto a partir de los valores del análisis.
+	// Las filas se muestran contando desde 1 para el usuario.
+	veredicto := "es resoluble"
+	if !a.Resoluble {
+		veredicto = "NO es resoluble"
+	}
+	if !a.ColumnasPares {
+		return fmt.Sprintf("Con %d columnas (impar) los movimientos no cambian la paridad de las inversiones, "+
+			"por lo que solo se alcanza el objetivo con un número par de inversiones. "+
+			"El tablero tiene %d inversiones (%s): %s.",
+			columnas, a.Inversiones, nombreParidad(a.Inversiones), veredicto)
+	}
+	distancia := abs(a.FilaVacio - a.FilaVacioObjetivo)
+	return fmt.Sprintf("Con %d columnas (par) cada movimiento vertical cambia a la vez la paridad de las inversiones "+
+		"y la fila del vacío, por lo que la suma de ambas debe ser par. "+
+		"El tablero tiene %d inversiones y el vacío está en la fila %d (en el objetivo, fila %d): "+
+		"%d + %d = %d (%s), %s.",
+		columnas, a.Inversiones, a.FilaVacio+1, a.FilaVacioObjetivo+1,
+		a.Inversiones, distancia, a.Paridad, nombreParidad(a.Paridad), veredicto)
+}
+
+func nombreParidad(n int) string {
+	// nombreParidad retorna "par" o "impar" según el valor recibido.
+	if n%2 == 0 {
+		return "par"
+	}
+	return "impar"
+}
+
+func inversiones(tablero Tablero, objetivo Tablero) int {
+	// inversiones cuenta los pares de fichas (sin el vacío) que aparecen en el tablero en orden
+	// inverso al que tienen en el objetivo.
+	//
+	// Complejidad temporal: O(n²) con n = filas x columnas
+	var orden [MaxCeldas]int // orden[v]: índice de la ficha v al recorrer el objetivo sin el vacío
+	indice := 0
+	for i := 0; i < objetivo.Tamano(); i++ {
+		if valor := objetivo.celdas[i]; valor != 0 {
+			orden[valor] = indice
+			indice++
+		}
+	}
+
+	total := 0
+	for i := 0; i < tablero.Tamano(); i++ {
+		if tablero.celdas[i] == 0 {
+			continue
+		}
+		for j := i + 1; j < tablero.Tamano(); j++ {
+			if tablero.celdas[j] != 0 && orden[tablero.celdas[i]] > orden[tablero.celdas[j]] {
+				total++
+			}
+		}
+	}
+	return total
+}
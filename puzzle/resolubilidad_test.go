@@ -0,0 +1,93 @@
+package puzzle
+
+import "testing"
+
+func alcanzables(objetivo Tablero) *ConjuntoEstados {
+	// alcanzables retorna el conjunto de tableros a los que se llega con movimientos desde el objetivo.
+	conjunto := NuevoConjuntoEstados()
+	conjunto.Agregar(objetivo)
+	frontera := []Tablero{objetivo}
+	for len(frontera) > 0 {
+		tablero := frontera[len(frontera)-1]
+		frontera = frontera[:len(frontera)-1]
+		for _, sucesor := range GenerarMovimientos(tablero) {
+			if conjunto.Agregar(sucesor.Tablero) {
+				frontera = append(frontera, sucesor.Tablero)
+			}
+		}
+	}
+	return conjunto
+}
+
+func TestResolubilidadAlcanzable(t *testing.T) {
+	// La regla de paridad coincide con la alcanzabilidad real en todas las permutaciones, con
+	// columnas pares e impares y con objetivos distintos del estándar.
+	casos := []struct {
+		nombre   string
+		objetivo Tablero
+	}{
+		{"2x3 estándar", TableroObjetivo(2, 3)},
+		{"3x2 estándar", TableroObjetivo(3, 2)},
+		{"2x4 estándar", TableroObjetivo(2, 4)},
+		{"2x4 vacío al inicio", ObjetivoVacioInicial(2, 4)},
+		{"2x4 espiral", ObjetivoEspiral(2, 4)},
+		{"4x2 espiral", ObjetivoEspiral(4, 2)},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			alcanzable := alcanzables(c.objetivo)
+			tableros := permutaciones(c.objetivo.Filas(), c.objetivo.Columnas())
+			if alcanzable.Tamano() != len(tableros)/2 {
+				t.Fatalf("se alcanzan %d tableros, se esperaba la mitad de %d", alcanzable.Tamano(), len(tableros))
+			}
+			for _, tablero := range tableros {
+				if got, want := EsResoluble(tablero, c.objetivo), alcanzable.Contiene(tablero); got != want {
+					t.Fatalf("EsResoluble(%v) = %v, alcanzable = %v", tablero, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalizarResolubilidad(t *testing.T) {
+	// El análisis detalla las inversiones y la fila del vacío que deciden el veredicto.
+	casos := []struct {
+		nombre        string
+		tablero       string
+		objetivo      Tablero
+		resoluble     bool
+		inversiones   int
+		columnasPares bool
+		paridad       int
+	}{
+		{"impar resoluble", "1 2 3 4 5 6 0 7 8", TableroObjetivo(3, 3), true, 0, false, 0},
+		{"impar irresoluble", "2 1 3 4 5 6 7 8 0", TableroObjetivo(3, 3), false, 1, false, 1},
+		{"par, vacío una fila arriba", "1 2 3 0 5 6 7 4", TableroObjetivo(2, 4), true, 3, true, 4},
+		{"par irresoluble", "2 1 3 4 5 6 7 0", TableroObjetivo(2, 4), false, 1, true, 1},
+		{"par, objetivo con vacío al inicio", "1 0 2 3 4 5 6 7", ObjetivoVacioInicial(2, 4), true, 0, true, 0},
+		{"par, vacío al inicio y fila cambiada", "1 2 3 4 0 5 6 7", ObjetivoVacioInicial(2, 4), false, 0, true, 1},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			tablero, err := ParsearTablero(c.objetivo.Filas(), c.objetivo.Columnas(), c.tablero)
+			if err != nil {
+				t.Fatal(err)
+			}
+			a := AnalizarResolubilidad(tablero, c.objetivo)
+			if a.Resoluble != c.resoluble || a.Inversiones != c.inversiones || a.ColumnasPares != c.columnasPares || a.Paridad != c.paridad {
+				t.Fatalf("análisis = %+v, se esperaba resoluble %v, %d inversiones, columnas pares %v, paridad %d",
+					a, c.resoluble, c.inversiones, c.columnasPares, c.paridad)
+			}
+			if a.Explicacion == "" {
+				t.Fatal("el análisis no incluye la explicación")
+			}
+		})
+	}
+}
+
+func TestAnalizarResolubilidadDimensiones(t *testing.T) {
+	// Un tablero de otras dimensiones que el objetivo nunca es resoluble, aunque tenga las mismas casillas.
+	if a := AnalizarResolubilidad(TableroObjetivo(2, 3), TableroObjetivo(3, 2)); a.Resoluble || a.Explicacion == "" {
+		t.Fatalf("análisis = %+v, se esperaba irresoluble con explicación", a)
+	}
+}
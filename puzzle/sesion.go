@@ -0,0 +1,135 @@
+package puzzle
+
+// Sesion es una partida sobre un tablero con historial de movimientos: registra cada estado
+// alcanzado y la acción aplicada, y permite deshacer y rehacer. Es independiente de la
+// interfaz gráfica, por lo que sirve tanto para el juego manual como para recorrer soluciones.
+type Sesion struct {
+	objetivo Tablero  // Configuración que resuelve la partida
+	pasos    []Estado // pasos[0] es el tablero inicial; los siguientes, cada movimiento aplicado o deshecho
+	actual   int      // Índice en pasos del tablero vigente; los posteriores pueden rehacerse
+}
+
+func NuevaSesion(inicial, objetivo Tablero) *Sesion {
+	// NuevaSesion inicia una partida en el tablero inicial, sin movimientos en el historial.
+	return &Sesion{objetivo: objetivo, pasos: []Estado{{Tablero: inicial}}}
+}
+
+func (s *Sesion) Tablero() Tablero {
+	// Tablero retorna la configuración vigente de la partida.
+	return s.pasos[s.actual].Tablero
+}
+
+func (s *Sesion) Objetivo() Tablero {
+	// Objetivo retorna la configuración que resuelve la partida.
+	return s.objetivo
+}
+
+func (s *Sesion) Resuelta() bool {
+	// Resuelta indica si el tablero vigente coincide con el objetivo.
+	return EsObjetivo(s.Tablero(), s.objetivo)
+}
+
+func (s *Sesion) Movimientos() int {
+	// Movimientos retorna el número de movimientos aplicados desde el tablero inicial
+	// (los deshechos no cuentan).
+	return s.actual
+}
+
+func (s *Sesion) Historial() []Estado {
+	// Historial retorna los estados alcanzados desde el inicial hasta el vigente, inclusive,
+	// con la acción que llevó a cada uno. El primero corresponde al tablero inicial.
+	return append([]Estado(nil), s.pasos[:s.actual+1]...)
+}
+
+func (s *Sesion) Mover(accion string) bool {
+	// Mover desplaza el espacio vacío en la dirección indicada ("Arriba", "Abajo", "Izquierda"
+	// o "Derecha"). Un movimiento nuevo descarta los movimientos que podían rehacerse.
+	//
+	// RETORNA: false si el movimiento no es válido desde el tablero vigente
+	for _, sucesor := range GenerarMovimientos(s.Tablero()) {
+		if sucesor.Accion == accion {
+			s.aplicar(sucesor)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Sesion) MoverFicha(pos int) bool {
+	// MoverFicha desliza la ficha de la posición indicada hacia el espacio vacío.
+	//
+	// RETORNA: false si la ficha no es adyacente al vacío
+	for _, sucesor := range GenerarMovimientos(s.Tablero()) {
+		if pos >= 0 && pos < sucesor.Tablero.Tamano() && sucesor.Tablero.Valor(pos) == 0 {
+			s.aplicar(sucesor)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Sesion) aplicar(sucesor Estado) {
+	// aplicar agrega el sucesor al historial después del tablero vigente, descartando lo que
+	// podía rehacerse. Si el sucesor es justo el siguiente paso rehacible, lo conserva.
+	if s.actual+1 < len(s.pasos) && s.pasos[s.actual+1].Tablero == sucesor.Tablero {
+		s.actual++
+		return
+	}
+	sucesor.Costo = s.actual + 1
+	s.pasos = append(s.pasos[:s.actual+1], sucesor)
+	s.actual++
+}
+
+func (s *Sesion) CargarCamino(camino []Estado) bool {
+	// CargarCamino registra un camino que parte del tablero vigente, por ejemplo la solución de
+	// una búsqueda, como movimientos por rehacer: Rehacer avanza por el camino y Deshacer
+	// retrocede, sin perder el historial anterior al tablero vigente.
+	//
+	// RETORNA: false si el camino no parte del tablero vigente o contiene un movimiento inválido;
+	// en ese caso la sesión queda intacta, incluidos los movimientos que podían rehacerse
+	if len(camino) == 0 || camino[0].Tablero != s.Tablero() {
+		return false
+	}
+	pasos, inicio := s.pasos, s.actual
+	s.pasos = append([]Estado(nil), s.pasos[:inicio+1]...) // Copia: Mover no debe pisar los pasos originales
+	for _, estado := range camino[1:] {
+		if !s.Mover(estado.Accion) || s.Tablero() != estado.Tablero {
+			s.pasos, s.actual = pasos, inicio
+			return false
+		}
+	}
+	s.actual = inicio
+	return true
+}
+
+func (s *Sesion) PuedeDeshacer() bool {
+	// PuedeDeshacer indica si hay movimientos aplicados.
+	return s.actual > 0
+}
+
+func (s *Sesion) PuedeRehacer() bool {
+	// PuedeRehacer indica si hay movimientos deshechos que pueden volver a aplicarse.
+	return s.actual+1 < len(s.pasos)
+}
+
+func (s *Sesion) Deshacer() (Estado, bool) {
+	// Deshacer revierte el último movimiento aplicado.
+	//
+	// RETORNA: el estado deshecho (con su acción) y false si no había movimientos
+	if !s.PuedeDeshacer() {
+		return Estado{}, false
+	}
+	s.actual--
+	return s.pasos[s.actual+1], true
+}
+
+func (s *Sesion) Rehacer() (Estado, bool) {
+	// Rehacer vuelve a aplicar el último movimiento deshecho.
+	//
+	// RETORNA: el estado alcanzado (con su acción) y false si no había nada que rehacer
+	if !s.PuedeRehacer() {
+		return Estado{}, false
+	}
+	s.actual++
+	return s.pasos[s.actual], true
+}
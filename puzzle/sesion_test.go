@@ -0,0 +1,151 @@
+package puzzle
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSesionDeshacerRehacer(t *testing.T) {
+	// Deshacer y rehacer recorren el historial; un movimiento nuevo descarta lo que podía
+	// rehacerse, salvo que repita justo el siguiente paso.
+	objetivo := TableroObjetivo(3, 3)
+	sesion := NuevaSesion(objetivo, objetivo)
+	if !sesion.Resuelta() || sesion.PuedeDeshacer() || sesion.PuedeRehacer() {
+		t.Fatal("una sesión nueva en el objetivo debe estar resuelta y sin historial")
+	}
+	if sesion.Mover("Abajo") || sesion.Mover("Derecha") {
+		t.Fatal("el vacío en la esquina inferior derecha no puede moverse hacia abajo ni a la derecha")
+	}
+
+	pasos := []string{"Arriba", "Izquierda", "Abajo"}
+	tableros := []Tablero{sesion.Tablero()}
+	for _, accion := range pasos {
+		if !sesion.Mover(accion) {
+			t.Fatalf("movimiento %q rechazado", accion)
+		}
+		tableros = append(tableros, sesion.Tablero())
+	}
+	if sesion.Movimientos() != 3 || len(sesion.Historial()) != 4 || sesion.Resuelta() {
+		t.Fatalf("tras 3 movimientos: %d movimientos, historial de %d", sesion.Movimientos(), len(sesion.Historial()))
+	}
+
+	for i := len(pasos) - 1; i >= 0; i-- {
+		estado, ok := sesion.Deshacer()
+		if !ok || estado.Accion != pasos[i] || sesion.Tablero() != tableros[i] {
+			t.Fatalf("deshacer %d: %q, %v", i, estado.Accion, ok)
+		}
+	}
+	if _, ok := sesion.Deshacer(); ok || !sesion.Resuelta() {
+		t.Fatal("no debería poder deshacerse más allá del tablero inicial")
+	}
+	if estado, ok := sesion.Rehacer(); !ok || estado.Accion != "Arriba" || sesion.Tablero() != tableros[1] {
+		t.Fatalf("rehacer: %q, %v", estado.Accion, ok)
+	}
+
+	// Repetir el siguiente paso conserva lo rehacible; otro movimiento lo descarta
+	sesion.Mover("Izquierda")
+	if !sesion.PuedeRehacer() || sesion.Tablero() != tableros[2] {
+		t.Fatal("repetir el paso siguiente no debería descartar lo rehacible")
+	}
+	sesion.Mover("Derecha")
+	if sesion.PuedeRehacer() || sesion.Movimientos() != 3 {
+		t.Fatalf("un movimiento nuevo debe descartar lo rehacible (%d movimientos)", sesion.Movimientos())
+	}
+}
+
+func TestSesionMoverFicha(t *testing.T) {
+	// Solo se desliza una ficha adyacente al vacío.
+	objetivo := TableroObjetivo(3, 3)
+	sesion := NuevaSesion(objetivo, objetivo)
+	for _, pos := range []int{-1, 0, 4, 8, 9} {
+		if sesion.MoverFicha(pos) {
+			t.Fatalf("la ficha %d no es adyacente al vacío", pos)
+		}
+	}
+	if !sesion.MoverFicha(5) || EncontrarVacio(sesion.Tablero()) != 5 {
+		t.Fatal("la ficha 5 debería deslizarse al vacío")
+	}
+}
+
+func TestSesionCargarCamino(t *testing.T) {
+	// CargarCamino deja la solución como movimientos por rehacer sin cambiar el tablero vigente,
+	// conserva el historial previo y rechaza caminos ajenos o inválidos sin modificar la sesión.
+	objetivo := TableroObjetivo(3, 3)
+	inicial := MezclarAleatorio(objetivo, 30, NuevoGenerador(1))
+	sesion := NuevaSesion(inicial, objetivo)
+	sesion.Mover(GenerarMovimientos(inicial)[0].Accion)
+	vigente := sesion.Tablero()
+
+	resultado, err := BusquedaAEstrella(context.Background(), vigente, objetivo, NuevaHeuristicaConflictoLineal(objetivo), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	camino := resultado.Camino
+
+	casos := []struct {
+		nombre string
+		camino []Estado
+	}{
+		{"vacío", nil},
+		{"desde otro tablero", camino[1:]},
+		{"con un paso inválido", append(append([]Estado{}, camino[:2]...), Estado{Tablero: camino[1].Tablero, Accion: "Arriba"})},
+	}
+	for _, c := range casos {
+		if sesion.CargarCamino(c.camino) {
+			t.Fatalf("camino %s aceptado", c.nombre)
+		}
+		if sesion.Tablero() != vigente || sesion.PuedeRehacer() || sesion.Movimientos() != 1 {
+			t.Fatalf("el camino %s rechazado modificó la sesión", c.nombre)
+		}
+	}
+
+	if !sesion.CargarCamino(camino) {
+		t.Fatal("la solución desde el tablero vigente fue rechazada")
+	}
+	if sesion.Tablero() != vigente || sesion.Movimientos() != 1 || !sesion.PuedeRehacer() {
+		t.Fatal("cargar el camino no debe mover el tablero vigente")
+	}
+	for i := 1; i < len(camino); i++ {
+		if estado, ok := sesion.Rehacer(); !ok || estado.Tablero != camino[i].Tablero {
+			t.Fatalf("rehacer el paso %d: %v", i, ok)
+		}
+	}
+	if !sesion.Resuelta() || sesion.PuedeRehacer() || sesion.Movimientos() != len(camino) {
+		t.Fatalf("tras rehacer la solución: resuelta %v, %d movimientos", sesion.Resuelta(), sesion.Movimientos())
+	}
+	for sesion.PuedeDeshacer() {
+		sesion.Deshacer()
+	}
+	if sesion.Tablero() != inicial {
+		t.Fatal("deshacer todo debe volver al tablero inicial, anterior al camino cargado")
+	}
+}
+
+func TestSesionCargarCaminoRechazadoConservaRehacer(t *testing.T) {
+	// Un camino rechazado no descarta los movimientos que podían rehacerse antes de cargarlo.
+	objetivo := TableroObjetivo(3, 3)
+	sesion := NuevaSesion(objetivo, objetivo)
+	sesion.Mover("Arriba")
+	sesion.Mover("Izquierda")
+	sesion.Deshacer()
+	sesion.Deshacer()
+	historial := append([]Estado(nil), sesion.pasos...)
+
+	invalido := []Estado{{Tablero: objetivo}, {Tablero: objetivo, Accion: "Arriba"}}
+	if sesion.CargarCamino(invalido) {
+		t.Fatal("camino inválido aceptado")
+	}
+	if sesion.Tablero() != objetivo || len(sesion.pasos) != len(historial) {
+		t.Fatalf("el historial cambió: %d pasos, antes %d", len(sesion.pasos), len(historial))
+	}
+	for i := range historial {
+		if sesion.pasos[i] != historial[i] {
+			t.Fatalf("el paso %d cambió tras rechazar el camino", i)
+		}
+	}
+	for _, accion := range []string{"Arriba", "Izquierda"} {
+		if estado, ok := sesion.Rehacer(); !ok || estado.Accion != accion {
+			t.Fatalf("rehacer %q: %q, %v", accion, estado.Accion, ok)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package puzzle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+)
+
+// firmaTablaDistancias identifica los archivos binarios generados por TablaDistancias.Guardar.
+var firmaTablaDistancias = [4]byte{'D', 'I', 'S', '1'}
+
+// MaxCeldasTablaDistancias es el mayor tablero que admite una tabla de distancias completa:
+// 3x3 (181.440 estados). El siguiente tamaño, 3x4, tendría 239.500.800 estados.
+const MaxCeldasTablaDistancias = 9
+
+// TablaDistancias guarda la distancia óptima exacta de todos los estados resolubles de un tablero
+// pequeño hacia un objetivo, calculada con una BFS completa desde el objetivo. En el 8-puzzle son
+// 181.440 estados (9!/2), uno por byte.
+//
+// INDEXACIÓN:
+// Un estado resoluble queda determinado por las posiciones del vacío y de las fichas 1..celdas-3:
+// las dos fichas restantes solo pueden ocupar las dos casillas libres en el orden que respeta la
+// paridad. El índice es el rango de esa variación (como en los patrones de BasePatrones), que
+// recorre exactamente los celdas!/2 estados resolubles sin huecos.
+type TablaDistancias struct {
+	objetivo Tablero // Configuración objetivo desde la que se midieron las distancias
+	indice   patron  // Rango de las posiciones del vacío y las fichas 1..celdas-3; tabla guarda las distancias
+}
+
+func ConstruirTablaDistancias(objetivo Tablero, progreso func(float64)) (*TablaDistancias, error) {
+	// ConstruirTablaDistancias enumera con una BFS todos los estados resolubles del tablero,
+	// partiendo del objetivo, y registra la profundidad a la que se alcanza cada uno, que es
+	// su distancia óptima.
+	//
+	// PARÁMETROS:
+	// - objetivo: configuración objetivo (cualquiera) de hasta MaxCeldasTablaDistancias casillas
+	// - progreso: función opcional (puede ser nil) que recibe el avance entre 0 y 1
+	//
+	// RETORNA: la tabla completa, o un error si el tablero es demasiado grande
+	//
+	// Complejidad: O(celdas!/2) en tiempo y memoria; unos 100 ms para el 8-puzzle
+	if objetivo.Tamano() > MaxCeldasTablaDistancias {
+		return nil, fmt.Errorf("la tabla de distancias admite hasta %d casillas, el tablero %dx%d tiene %d",
+			MaxCeldasTablaDistancias, objetivo.filas, objetivo.columnas, objetivo.Tamano())
+	}
+	t := nuevaTablaDistancias(objetivo)
+	for i := range t.indice.tabla {
+		t.indice.tabla[i] = distanciaDesconocida
+	}
+
+	t.indice.tabla[t.rango(objetivo)] = 0
+	cola := make([]Tablero, 1, len(t.indice.tabla))
+	cola[0] = objetivo
+	for frente := 0; frente < len(cola); frente++ {
+		distancia := t.indice.tabla[t.rango(cola[frente])]
+		for _, sucesor := range GenerarMovimientos(cola[frente]) {
+			if r := t.rango(sucesor.Tablero); t.indice.tabla[r] == distanciaDesconocida {
+				t.indice.tabla[r] = distancia + 1
+				cola = append(cola, sucesor.Tablero)
+			}
+		}
+		if progreso != nil && frente%(1<<14) == 0 {
+			progreso(float64(frente) / float64(len(t.indice.tabla)))
+		}
+	}
+	if progreso != nil {
+		progreso(1)
+	}
+	return t, nil
+}
+
+func nuevaTablaDistancias(objetivo Tablero) *TablaDistancias {
+	// nuevaTablaDistancias reserva una tabla vacía para el objetivo con el índice de sus dimensiones.
+	celdas := objetivo.Tamano()
+	fichas := make([]int, celdas-2) // El vacío y las fichas 1..celdas-3
+	for i := range fichas {
+		fichas[i] = i
+	}
+	return &TablaDistancias{objetivo: objetivo, indice: nuevoPatron(celdas, fichas)}
+}
+
+func (t *TablaDistancias) rango(tablero Tablero) int {
+	// rango calcula el índice de un tablero resoluble a partir de las posiciones del vacío y de
+	// las fichas 1..celdas-3.
+	celdas := tablero.Tamano()
+	var posiciones [MaxCeldasTablaDistancias]int
+	for pos := 0; pos < celdas; pos++ {
+		if valor := int(tablero.celdas[pos]); valor < celdas-2 {
+			posiciones[valor] = pos
+		}
+	}
+	return t.indice.rango(posiciones[:celdas-2], celdas)
+}
+
+func (t *TablaDistancias) tablero(indice int) Tablero {
+	// tablero es la operación inversa de rango: reconstruye el estado resoluble de ese índice
+	// ubicando las dos fichas restantes en el orden que respeta la paridad.
+	celdas := t.objetivo.Tamano()
+	var posiciones [MaxCeldasTablaDistancias]int
+	t.indice.desordenar(indice, celdas, posiciones[:celdas-2])
+
+	tablero := Tablero{filas: t.objetivo.filas, columnas: t.objetivo.columnas}
+	ocupadas := uint64(0)
+	for valor, pos := range posiciones[:celdas-2] {
+		tablero.celdas[pos] = uint8(valor)
+		ocupadas |= 1 << pos
+	}
+	libres := make([]int, 0, 2)
+	for pos := 0; pos < celdas; pos++ {
+		if ocupadas&(1<<pos) == 0 {
+			libres = append(libres, pos)
+		}
+	}
+	tablero.celdas[libres[0]], tablero.celdas[libres[1]] = uint8(celdas-2), uint8(celdas-1)
+	if !EsResoluble(tablero, t.objetivo) {
+		tablero = tablero.Intercambiar(libres[0], libres[1])
+	}
+	return tablero
+}
+
+func (t *TablaDistancias) Objetivo() Tablero {
+	// Objetivo retorna la configuración desde la que se midieron las distancias.
+	return t.objetivo
+}
+
+func (t *TablaDistancias) Estados() int {
+	// Estados retorna el número de estados resolubles de la tabla (181.440 en el 8-puzzle).
+	return len(t.indice.tabla)
+}
+
+func (t *TablaDistancias) Distancia(tablero Tablero) int {
+	// Distancia retorna la longitud de la solución óptima del tablero hacia el objetivo de la
+	// tabla, con una sola consulta. El tablero debe tener las dimensiones del objetivo y ser
+	// resoluble (ver EsResoluble): un tablero irresoluble comparte índice con uno resoluble.
+	//
+	// Complejidad temporal: O(celdas²) para calcular el índice, constante para un tamaño dado
+	return int(t.indice.tabla[t.rango(tablero)])
+}
+
+func (t *TablaDistancias) Evaluar(tablero Tablero) int {
+	// Evaluar es la heurística perfecta h(n) = distancia óptima. Tiene la firma de Heuristica,
+	// por lo que t.Evaluar puede pasarse a BusquedaAEstrella o BusquedaIDAEstrella (que solo
+	// expanden el camino óptimo) o servir de referencia para validar otras heurísticas.
+	return t.Distancia(tablero)
+}
+
+func (t *TablaDistancias) Histograma() []int {
+	// Histograma retorna cuántos estados hay a cada distancia: el elemento d cuenta los estados
+	// cuya solución óptima tiene d movimientos. Su longitud es el diámetro más uno (32 en el 8-puzzle).
+	histograma := []int{}
+	for _, distancia := range t.indice.tabla {
+		for int(distancia) >= len(histograma) {
+			histograma = append(histograma, 0)
+		}
+		histograma[distancia]++
+	}
+	return histograma
+}
+
+func (t *TablaDistancias) ADistancia(distancia int) []Tablero {
+	// ADistancia retorna todos los estados cuya solución óptima tiene exactamente distancia
+	// movimientos, en orden de índice. Con la distancia máxima (len(Histograma())-1) retorna
+	// las posiciones más difíciles, por ejemplo los dos tableros a 31 movimientos del 8-puzzle.
+	tableros := []Tablero{}
+	for indice, d := range t.indice.tabla {
+		if int(d) == distancia {
+			tableros = append(tableros, t.tablero(indice))
+		}
+	}
+	return tableros
+}
+
+func (t *TablaDistancias) Aleatorio(distancia int, rng *rand.Rand) (Tablero, bool) {
+	// Aleatorio elige un estado uniformemente entre todos los que están exactamente a distancia
+	// movimientos del objetivo.
+	//
+	// RETORNA: el tablero, o false si no hay estados a esa distancia
+	histograma := t.Histograma()
+	if distancia < 0 || distancia >= len(histograma) {
+		return Tablero{}, false
+	}
+	elegido := rng.IntN(histograma[distancia])
+	for indice, d := range t.indice.tabla {
+		if int(d) != distancia {
+			continue
+		}
+		if elegido == 0 {
+			return t.tablero(indice), true
+		}
+		elegido--
+	}
+	return Tablero{}, false // No debería ocurrir
+}
+
+func (t *TablaDistancias) Recorrer(visitar func(tablero Tablero, distancia int)) {
+	// Recorrer llama a visitar con cada estado resoluble y su distancia óptima, en orden de índice.
+	// Sirve para validar otras heurísticas comparándolas con la distancia exacta en todo el espacio.
+	for indice, d := range t.indice.tabla {
+		visitar(t.tablero(indice), int(d))
+	}
+}
+
+func (t *TablaDistancias) Guardar(w io.Writer) error {
+	// Guardar serializa la tabla en formato binario compacto: firma "DIS1", filas y columnas
+	// (un byte cada una), las casillas del objetivo y las distancias (un byte por estado).
+	escritor := bufio.NewWriter(w)
+	escritor.Write(firmaTablaDistancias[:])
+	escritor.Write([]byte{byte(t.objetivo.filas), byte(t.objetivo.columnas)})
+	escritor.Write(t.objetivo.celdas[:t.objetivo.Tamano()])
+	escritor.Write(t.indice.tabla)
+	return escritor.Flush()
+}
+
+func LeerTablaDistancias(r io.Reader) (*TablaDistancias, error) {
+	// LeerTablaDistancias deserializa una tabla escrita por Guardar.
+	// Retorna error si el formato no es válido o el contenido está truncado.
+	lector := bufio.NewReader(r)
+	var encabezado struct {
+		Firma    [4]byte
+		Filas    uint8
+		Columnas uint8
+	}
+	if err := binary.Read(lector, binary.LittleEndian, &encabezado); err != nil {
+		return nil, fmt.Errorf("encabezado de tabla de distancias inválido: %w", err)
+	}
+	if encabezado.Firma != firmaTablaDistancias {
+		return nil, errors.New("el archivo no es una tabla de distancias")
+	}
+	celdas := int(encabezado.Filas) * int(encabezado.Columnas)
+	if celdas > MaxCeldasTablaDistancias {
+		return nil, fmt.Errorf("tamaño %dx%d no admitido", encabezado.Filas, encabezado.Columnas)
+	}
+
+	casillas := make([]byte, celdas)
+	if _, err := io.ReadFull(lector, casillas); err != nil {
+		return nil, fmt.Errorf("objetivo truncado: %w", err)
+	}
+	valores := make([]int, celdas)
+	for i, valor := range casillas {
+		valores[i] = int(valor)
+	}
+	objetivo, err := NuevoTablero(int(encabezado.Filas), int(encabezado.Columnas), valores)
+	if err != nil {
+		return nil, fmt.Errorf("objetivo inválido: %w", err)
+	}
+	t := nuevaTablaDistancias(objetivo)
+	if _, err := io.ReadFull(lector, t.indice.tabla); err != nil {
+		return nil, fmt.Errorf("distancias truncadas: %w", err)
+	}
+	return t, nil
+}
+
+func RutaCacheTablaDistancias(objetivo Tablero) (string, error) {
+	// RutaCacheTablaDistancias retorna la ruta del archivo de caché de la tabla de un objetivo,
+	// junto a las bases de patrones (ver RutaCacheBasePatrones).
+	directorio, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	hash := fnv.New32a()
+	hash.Write(objetivo.celdas[:objetivo.Tamano()])
+	nombre := fmt.Sprintf("distancias-%dx%d-%08x.bin", objetivo.filas, objetivo.columnas, hash.Sum32())
+	return filepath.Join(directorio, "puzzle-solver", nombre), nil
+}
+
+func CargarTablaDistancias(ruta string, objetivo Tablero, progreso func(float64)) (*TablaDistancias, error) {
+	// CargarTablaDistancias obtiene la tabla de forma perezosa: si el archivo de caché existe y
+	// corresponde al objetivo se lee del disco; en caso contrario se construye con
+	// ConstruirTablaDistancias y se guarda para la próxima ejecución.
+	//
+	// RETORNA: la tabla y, si no pudo guardarse la caché, un error informativo. En ese caso la
+	// tabla retornada es válida y puede utilizarse igualmente.
+	if datos, err := os.ReadFile(ruta); err == nil {
+		if t, err := LeerTablaDistancias(bytes.NewReader(datos)); err == nil && t.objetivo == objetivo {
+			if progreso != nil {
+				progreso(1)
+			}
+			return t, nil
+		}
+	}
+
+	t, err := ConstruirTablaDistancias(objetivo, progreso)
+	if err != nil {
+		return nil, err
+	}
+	if err := guardarArchivo(ruta, t.Guardar); err != nil {
+		return t, fmt.Errorf("no se pudo guardar la caché de la tabla de distancias: %w", err)
+	}
+	return t, nil
+}
+
+func ObtenerTablaDistancias(objetivo Tablero, progreso func(float64)) (*TablaDistancias, error) {
+	// ObtenerTablaDistancias retorna la tabla de distancias desde la caché en disco del usuario
+	// (ver RutaCacheTablaDistancias y CargarTablaDistancias) o, si no hay un directorio de caché
+	// disponible, la construye en memoria sin guardarla.
+	//
+	// RETORNA: la tabla, o nil y un error si el objetivo tiene más de MaxCeldasTablaDistancias
+	// casillas. Si la tabla no es nil, el error es informativo (no pudo usarse la caché) y la
+	// tabla puede utilizarse igualmente.
+	ruta, err := RutaCacheTablaDistancias(objetivo)
+	if err != nil {
+		t, errConstruir := ConstruirTablaDistancias(objetivo, progreso)
+		if errConstruir != nil {
+			return nil, errConstruir
+		}
+		return t, fmt.Errorf("sin directorio de caché, la tabla de distancias no se guardará: %w", err)
+	}
+	return CargarTablaDistancias(ruta, objetivo, progreso)
+}
@@ -0,0 +1,135 @@
+package puzzle
+
+import (
+	"bytes"
+	"testing"
+)
+
+func distanciasBFS(objetivo Tablero) map[Tablero]int {
+	// distanciasBFS calcula la distancia de cada estado alcanzable con una búsqueda en anchura
+	// desde el objetivo, como referencia independiente de la tabla.
+	distancias := map[Tablero]int{objetivo: 0}
+	frontera := []Tablero{objetivo}
+	for len(frontera) > 0 {
+		tablero := frontera[0]
+		frontera = frontera[1:]
+		for _, sucesor := range GenerarMovimientos(tablero) {
+			if _, visto := distancias[sucesor.Tablero]; !visto {
+				distancias[sucesor.Tablero] = distancias[tablero] + 1
+				frontera = append(frontera, sucesor.Tablero)
+			}
+		}
+	}
+	return distancias
+}
+
+func TestTablaDistanciasExacta(t *testing.T) {
+	// Cada índice de la tabla corresponde a un estado resoluble distinto, Distancia lo vuelve a
+	// encontrar y su valor coincide con la búsqueda en anchura, para objetivos arbitrarios.
+	casos := []struct {
+		nombre   string
+		objetivo Tablero
+	}{
+		{"2x3 estándar", TableroObjetivo(2, 3)},
+		{"3x2 espiral", ObjetivoEspiral(3, 2)},
+		{"2x4 vacío al inicio", ObjetivoVacioInicial(2, 4)},
+		{"3x3 estándar", TableroObjetivo(3, 3)},
+		{"3x3 espiral", ObjetivoEspiral(3, 3)},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			tabla, err := ConstruirTablaDistancias(c.objetivo, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			referencia := distanciasBFS(c.objetivo)
+			if tabla.Estados() != len(referencia) {
+				t.Fatalf("la tabla tiene %d estados, la búsqueda en anchura alcanza %d", tabla.Estados(), len(referencia))
+			}
+
+			vistos := NuevoConjuntoEstados()
+			tabla.Recorrer(func(tablero Tablero, distancia int) {
+				if !vistos.Agregar(tablero) {
+					t.Fatalf("%v aparece dos veces", tablero)
+				}
+				if want, alcanzable := referencia[tablero]; !alcanzable || distancia != want {
+					t.Fatalf("%v: distancia %d, la búsqueda en anchura da %d (alcanzable %v)", tablero, distancia, want, alcanzable)
+				}
+				if got := tabla.Distancia(tablero); got != distancia {
+					t.Fatalf("%v: Distancia = %d, Recorrer informa %d", tablero, got, distancia)
+				}
+			})
+
+			total := 0
+			for d, cantidad := range tabla.Histograma() {
+				estados := tabla.ADistancia(d)
+				if len(estados) != cantidad {
+					t.Fatalf("ADistancia(%d) retorna %d estados, el histograma cuenta %d", d, len(estados), cantidad)
+				}
+				for _, tablero := range estados {
+					if referencia[tablero] != d {
+						t.Fatalf("ADistancia(%d) incluye %v, a distancia %d", d, tablero, referencia[tablero])
+					}
+				}
+				total += cantidad
+			}
+			if total != tabla.Estados() {
+				t.Fatalf("el histograma suma %d estados de %d", total, tabla.Estados())
+			}
+		})
+	}
+}
+
+func TestTablaDistanciasAleatorio(t *testing.T) {
+	// Aleatorio elige estados a la distancia pedida y rechaza las distancias fuera del histograma.
+	objetivo := TableroObjetivo(3, 3)
+	tabla, err := ConstruirTablaDistancias(objetivo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diametro := len(tabla.Histograma()) - 1; diametro != 31 {
+		t.Fatalf("diámetro del 8-puzzle = %d, se esperaba 31", diametro)
+	}
+	rng := NuevoGenerador(1)
+	for _, d := range []int{0, 1, 15, 31} {
+		tablero, existe := tabla.Aleatorio(d, rng)
+		if !existe || tabla.Distancia(tablero) != d {
+			t.Fatalf("Aleatorio(%d) = %v (existe %v) a distancia %d", d, tablero, existe, tabla.Distancia(tablero))
+		}
+	}
+	for _, d := range []int{-1, 32} {
+		if _, existe := tabla.Aleatorio(d, rng); existe {
+			t.Fatalf("Aleatorio(%d) no debería encontrar estados", d)
+		}
+	}
+}
+
+func TestTablaDistanciasGuardarLeer(t *testing.T) {
+	// La tabla leída de su serialización es idéntica a la original.
+	objetivo := ObjetivoEspiral(2, 4)
+	tabla, err := ConstruirTablaDistancias(objetivo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buffer bytes.Buffer
+	if err := tabla.Guardar(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	leida, err := LeerTablaDistancias(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leida.Objetivo() != objetivo || !bytes.Equal(leida.indice.tabla, tabla.indice.tabla) {
+		t.Fatal("la tabla leída no coincide con la guardada")
+	}
+	if _, err := LeerTablaDistancias(bytes.NewReader([]byte("PDB3"))); err == nil {
+		t.Fatal("se esperaba un error con una firma incorrecta")
+	}
+}
+
+func TestTablaDistanciasTamanoMaximo(t *testing.T) {
+	// Los tableros de más de MaxCeldasTablaDistancias casillas no admiten tabla.
+	if _, err := ConstruirTablaDistancias(TableroObjetivo(3, 4), nil); err == nil {
+		t.Fatal("se esperaba un error para un tablero de 3x4")
+	}
+}